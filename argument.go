@@ -1,10 +1,22 @@
 package sqlmock
 
-import "database/sql/driver"
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
 
 // Argument interface allows to match
 // any argument in specific way when used with
 // ExpectedQuery and ExpectedExec expectations.
+//
+// If an Argument implements fmt.Stringer, its String
+// describes it in expectations and in mismatch errors.
 type Argument interface {
 	Match(driver.Value) bool
 }
@@ -22,3 +34,424 @@ type anyArgument struct{}
 func (a anyArgument) Match(_ driver.Value) bool {
 	return true
 }
+
+func (a anyArgument) String() string {
+	return "any value"
+}
+
+// Eq will return an Argument which matches a value equal to the given one.
+// Numbers are equal regardless of their type, so that Eq(1) matches
+// int64(1) or float64(1), times are compared with time.Time.Equal.
+func Eq(value interface{}) Argument {
+	if valuer, ok := value.(driver.Valuer); ok {
+		if v, err := valuer.Value(); err == nil {
+			value = v
+		}
+	}
+	return eqArgument{value: value}
+}
+
+type eqArgument struct {
+	value interface{}
+}
+
+func (a eqArgument) Match(v driver.Value) bool {
+	return valuesEqual(a.value, v)
+}
+
+func (a eqArgument) String() string {
+	return "equal to " + formatValue(a.value)
+}
+
+// Regex will return an Argument which matches a string or []byte
+// value against the given regular expression. It panics, if the
+// expression can not be compiled.
+func Regex(expr string) Argument {
+	return regexArgument{re: regexp.MustCompile(expr)}
+}
+
+type regexArgument struct {
+	re *regexp.Regexp
+}
+
+func (a regexArgument) Match(v driver.Value) bool {
+	switch s := v.(type) {
+	case string:
+		return a.re.MatchString(s)
+	case []byte:
+		return a.re.Match(s)
+	}
+	return false
+}
+
+func (a regexArgument) String() string {
+	return fmt.Sprintf("matching regexp %q", a.re.String())
+}
+
+// OfType will return an Argument which matches any value
+// of the same type as the given example, like OfType(time.Time{}).
+func OfType(example interface{}) Argument {
+	return ofTypeArgument{typ: reflect.TypeOf(example)}
+}
+
+type ofTypeArgument struct {
+	typ reflect.Type
+}
+
+func (a ofTypeArgument) Match(v driver.Value) bool {
+	return reflect.TypeOf(v) == a.typ
+}
+
+func (a ofTypeArgument) String() string {
+	return fmt.Sprintf("of type %v", a.typ)
+}
+
+// Between will return an Argument which matches a number
+// or time from min to max, both inclusive.
+func Between(min, max interface{}) Argument {
+	return betweenArgument{min: min, max: max}
+}
+
+type betweenArgument struct {
+	min, max interface{}
+}
+
+func (a betweenArgument) Match(v driver.Value) bool {
+	low, ok := compareValues(a.min, v)
+	if !ok || low > 0 {
+		return false
+	}
+	high, ok := compareValues(v, a.max)
+	return ok && high <= 0
+}
+
+func (a betweenArgument) String() string {
+	return fmt.Sprintf("between %s and %s", formatValue(a.min), formatValue(a.max))
+}
+
+// In will return an Argument which matches a value
+// equal to one of the given ones, like Eq does.
+func In(values ...interface{}) Argument {
+	return inArgument{values: values}
+}
+
+type inArgument struct {
+	values []interface{}
+}
+
+func (a inArgument) Match(v driver.Value) bool {
+	for _, value := range a.values {
+		if valuesEqual(value, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a inArgument) String() string {
+	values := make([]string, len(a.values))
+	for i, v := range a.values {
+		values[i] = formatValue(v)
+	}
+	return "one of [" + strings.Join(values, ", ") + "]"
+}
+
+// Not will return an Argument which matches
+// any value the given Argument does not.
+func Not(arg Argument) Argument {
+	return notArgument{arg: arg}
+}
+
+type notArgument struct {
+	arg Argument
+}
+
+func (a notArgument) Match(v driver.Value) bool {
+	return !a.arg.Match(v)
+}
+
+func (a notArgument) capture(v driver.Value) {
+	if c, ok := a.arg.(capturer); ok {
+		c.capture(v)
+	}
+}
+
+func (a notArgument) String() string {
+	return "not " + describeArgument(a.arg)
+}
+
+// Nil will return an Argument which matches a NULL value.
+func Nil() Argument {
+	return nilArgument{}
+}
+
+type nilArgument struct{}
+
+func (a nilArgument) Match(v driver.Value) bool {
+	return v == nil
+}
+
+func (a nilArgument) String() string {
+	return "nil"
+}
+
+// NotNil will return an Argument which matches any value, but NULL.
+func NotNil() Argument {
+	return notArgument{arg: nilArgument{}}
+}
+
+// TimeWithin will return an Argument which matches a time
+// no further than tolerance from the given one.
+func TimeWithin(t time.Time, tolerance time.Duration) Argument {
+	return timeWithinArgument{t: t, tolerance: tolerance}
+}
+
+type timeWithinArgument struct {
+	t         time.Time
+	tolerance time.Duration
+}
+
+func (a timeWithinArgument) Match(v driver.Value) bool {
+	actual, ok := v.(time.Time)
+	if !ok {
+		return false
+	}
+	diff := actual.Sub(a.t)
+	return diff <= a.tolerance && diff >= -a.tolerance
+}
+
+func (a timeWithinArgument) String() string {
+	return fmt.Sprintf("time within %s of %s", a.tolerance, formatValue(a.t))
+}
+
+// JSONEq will return an Argument which matches a string or []byte
+// holding a JSON document equal to the given one, regardless of
+// formatting and of the order of object keys. It panics, if the
+// given document is not valid JSON.
+func JSONEq(document string) Argument {
+	var expected interface{}
+	if err := json.Unmarshal([]byte(document), &expected); err != nil {
+		panic(fmt.Errorf("could not unmarshal expected JSON %q: %s", document, err))
+	}
+	return jsonEqArgument{document: document, expected: expected}
+}
+
+type jsonEqArgument struct {
+	document string
+	expected interface{}
+}
+
+func (a jsonEqArgument) Match(v driver.Value) bool {
+	var data []byte
+	switch s := v.(type) {
+	case string:
+		data = []byte(s)
+	case []byte:
+		data = s
+	default:
+		return false
+	}
+	var actual interface{}
+	if err := json.Unmarshal(data, &actual); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(a.expected, actual)
+}
+
+func (a jsonEqArgument) String() string {
+	return "JSON equal to " + a.document
+}
+
+// BytesEq will return an Argument which matches a []byte or
+// string value holding exactly the given bytes.
+func BytesEq(b []byte) Argument {
+	return bytesEqArgument{b: b}
+}
+
+type bytesEqArgument struct {
+	b []byte
+}
+
+func (a bytesEqArgument) Match(v driver.Value) bool {
+	switch s := v.(type) {
+	case []byte:
+		return bytes.Equal(a.b, s)
+	case string:
+		return bytes.Equal(a.b, []byte(s))
+	}
+	return false
+}
+
+func (a bytesEqArgument) String() string {
+	return "bytes equal to " + formatValue(a.b)
+}
+
+// AllOf will return an Argument which matches
+// a value all of the given Arguments match.
+func AllOf(args ...Argument) Argument {
+	return allOfArgument{args: args}
+}
+
+type allOfArgument struct {
+	args []Argument
+}
+
+func (a allOfArgument) Match(v driver.Value) bool {
+	for _, arg := range a.args {
+		if !arg.Match(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a allOfArgument) capture(v driver.Value) {
+	for _, arg := range a.args {
+		if c, ok := arg.(capturer); ok {
+			c.capture(v)
+		}
+	}
+}
+
+func (a allOfArgument) String() string {
+	return "all of (" + describeArguments(a.args) + ")"
+}
+
+// AnyOf will return an Argument which matches
+// a value any of the given Arguments match.
+func AnyOf(args ...Argument) Argument {
+	return anyOfArgument{args: args}
+}
+
+type anyOfArgument struct {
+	args []Argument
+}
+
+func (a anyOfArgument) Match(v driver.Value) bool {
+	for _, arg := range a.args {
+		if arg.Match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// capture forwards the value to the first of the Arguments matching it.
+func (a anyOfArgument) capture(v driver.Value) {
+	for _, arg := range a.args {
+		if arg.Match(v) {
+			if c, ok := arg.(capturer); ok {
+				c.capture(v)
+			}
+			return
+		}
+	}
+}
+
+func (a anyOfArgument) String() string {
+	return "any of (" + describeArguments(a.args) + ")"
+}
+
+// describeArgument returns the String of an Argument
+// implementing fmt.Stringer, otherwise its type.
+func describeArgument(arg Argument) string {
+	if s, ok := arg.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%T", arg)
+}
+
+func describeArguments(args []Argument) string {
+	descriptions := make([]string, len(args))
+	for i, arg := range args {
+		descriptions[i] = describeArgument(arg)
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// formatValue prints an expected value for descriptions of Arguments.
+func formatValue(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return fmt.Sprintf("%q", value)
+	case []byte:
+		return fmt.Sprintf("%q", value)
+	case time.Time:
+		return value.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// valuesEqual compares an expected value with an actual one,
+// numbers regardless of their type and times by the instant.
+func valuesEqual(expected, actual interface{}) bool {
+	if cmp, ok := compareValues(expected, actual); ok {
+		return cmp == 0
+	}
+	if e, ok := expected.([]byte); ok {
+		a, ok := actual.([]byte)
+		return ok && bytes.Equal(e, a)
+	}
+	return reflect.DeepEqual(expected, actual)
+}
+
+// compareValues orders two numbers or two times, ok is false
+// if they are neither.
+func compareValues(a, b interface{}) (cmp int, ok bool) {
+	if ta, isTime := a.(time.Time); isTime {
+		tb, isTime := b.(time.Time)
+		if !isTime {
+			return 0, false
+		}
+		switch {
+		case ta.Before(tb):
+			return -1, true
+		case ta.After(tb):
+			return 1, true
+		}
+		return 0, true
+	}
+
+	ia, fa, intA, ok := toNumber(a)
+	if !ok {
+		return 0, false
+	}
+	ib, fb, intB, ok := toNumber(b)
+	if !ok {
+		return 0, false
+	}
+	if intA && intB {
+		switch {
+		case ia < ib:
+			return -1, true
+		case ia > ib:
+			return 1, true
+		}
+		return 0, true
+	}
+	switch {
+	case fa < fb:
+		return -1, true
+	case fa > fb:
+		return 1, true
+	}
+	return 0, true
+}
+
+// toNumber converts any integer or float to both int64 and float64,
+// isInt tells whether the int64 holds the exact value.
+func toNumber(v interface{}) (i int64, f float64, isInt, ok bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i = rv.Int()
+		return i, float64(i), true, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := rv.Uint()
+		return int64(u), float64(u), u <= 1<<63-1, true
+	case reflect.Float32, reflect.Float64:
+		f = rv.Float()
+		return int64(f), f, false, true
+	}
+	return 0, 0, false, false
+}
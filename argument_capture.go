@@ -0,0 +1,150 @@
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// capturer is implemented by Arguments recording the actual value,
+// once all arguments of the matched expectation were matched.
+type capturer interface {
+	capture(driver.Value)
+}
+
+// Captor is an Argument which matches any value assignable to T and
+// records it, once the query or exec it is expected with is matched.
+// Integers, floats, strings and []byte are converted to T, if needed,
+// numbers only if T represents them exactly.
+//
+//	var id string
+//	mock.ExpectExec("INSERT INTO users").WithArgs(sqlmock.Capture(&id), "john")
+type Captor[T any] struct {
+	mu       sync.Mutex
+	dst      *T
+	value    T
+	captured bool
+}
+
+// Capture will return a Captor which stores the captured value to dst,
+// which may be nil, if the value is only read from the Captor.
+func Capture[T any](dst *T) *Captor[T] {
+	return &Captor[T]{dst: dst}
+}
+
+// Match satisfies sqlmock.Argument interface
+func (c *Captor[T]) Match(v driver.Value) bool {
+	_, ok := convertCaptured[T](v)
+	return ok
+}
+
+func (c *Captor[T]) capture(v driver.Value) {
+	value, ok := convertCaptured[T](v)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.value, c.captured = value, true
+	if c.dst != nil {
+		*c.dst = value
+	}
+}
+
+// Value returns the last captured value and whether any was captured.
+func (c *Captor[T]) Value() (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.value, c.captured
+}
+
+// Same will return an Argument which matches a value equal to the one
+// this Captor captured, like Eq does. Since it is only resolved when
+// matched, it can be expected before the value is captured, for
+// instance with an exec which reuses a generated id.
+func (c *Captor[T]) Same() Argument {
+	return sameArgument[T]{captor: c}
+}
+
+func (c *Captor[T]) String() string {
+	var zero T
+	return fmt.Sprintf("captured into %T", &zero)
+}
+
+type sameArgument[T any] struct {
+	captor *Captor[T]
+}
+
+func (a sameArgument[T]) Match(v driver.Value) bool {
+	value, ok := a.captor.Value()
+	return ok && valuesEqual(value, v)
+}
+
+func (a sameArgument[T]) String() string {
+	if value, ok := a.captor.Value(); ok {
+		return "same as captured " + formatValue(value)
+	}
+	return "same as captured, but nothing was captured yet"
+}
+
+// CaptureFunc will return an Argument which matches any value and
+// passes it to fn, once the query or exec it is expected with is matched.
+func CaptureFunc(fn func(driver.Value)) Argument {
+	return captureFuncArgument{fn: fn}
+}
+
+type captureFuncArgument struct {
+	fn func(driver.Value)
+}
+
+func (a captureFuncArgument) Match(_ driver.Value) bool {
+	return true
+}
+
+func (a captureFuncArgument) capture(v driver.Value) {
+	a.fn(v)
+}
+
+func (a captureFuncArgument) String() string {
+	return "captured by func"
+}
+
+// convertCaptured converts an actual driver value to T.
+func convertCaptured[T any](v driver.Value) (T, bool) {
+	var zero T
+	if value, ok := v.(T); ok {
+		return value, true
+	}
+
+	typ := reflect.TypeOf(&zero).Elem()
+	if v == nil {
+		switch typ.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Slice, reflect.Map:
+			return zero, true
+		}
+		return zero, false
+	}
+
+	rv := reflect.ValueOf(v)
+	if !rv.Type().ConvertibleTo(typ) {
+		return zero, false
+	}
+	_, _, _, fromNumber := toNumber(v)
+	_, _, _, toNumeric := toNumber(reflect.Zero(typ).Interface())
+	isText := func(t reflect.Type) bool {
+		return t.Kind() == reflect.String || t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+	}
+	if isText(rv.Type()) && isText(typ) {
+		return rv.Convert(typ).Interface().(T), true
+	}
+	if fromNumber && toNumeric {
+		// a number which does not fit T, like 3.7 for an integer, does not match
+		converted := rv.Convert(typ)
+		if converted.Convert(rv.Type()).Interface() != v {
+			return zero, false
+		}
+		return converted.Interface().(T), true
+	}
+	return zero, false
+}
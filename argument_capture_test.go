@@ -0,0 +1,121 @@
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+func TestCaptureArgument(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	var id string
+	captor := Capture(&id)
+	var hash []byte
+	var calls int
+	mock.ExpectExec("INSERT INTO users").
+		WithArgs(captor, AllOf(Regex("^\\$2a\\$"), Capture(&hash)), CaptureFunc(func(driver.Value) { calls++ })).
+		WillReturnResult(NewResult(1, 1))
+	mock.ExpectExec("UPDATE users").
+		WithArgs("active", captor.Same()).
+		WillReturnResult(NewResult(0, 1))
+
+	if _, err := db.Exec("INSERT INTO users(id, password, age) VALUES (?, ?, ?)", "8c1e1b2a", "$2a$10$abc", 42); err != nil {
+		t.Fatalf("error '%s' was not expected, while inserting a row", err)
+	}
+	if id != "8c1e1b2a" || string(hash) != "$2a$10$abc" || calls != 1 {
+		t.Fatalf("unexpected captured values: %q, %q and %d calls", id, hash, calls)
+	}
+	if value, ok := captor.Value(); !ok || value != id {
+		t.Fatalf("expected the captor to hold %q, but got %q", id, value)
+	}
+
+	if _, err := db.Exec("UPDATE users SET status = ? WHERE id = ?", "active", "another"); err == nil {
+		t.Fatal("expected an error, since the id was not the captured one")
+	}
+	if _, err := db.Exec("UPDATE users SET status = ? WHERE id = ?", "active", "8c1e1b2a"); err != nil {
+		t.Fatalf("error '%s' was not expected, while updating a row", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCaptureOnlyWhenAllArgumentsMatch(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	var age int
+	mock.ExpectExec("UPDATE users").
+		WithArgs(Capture(&age), "john").
+		WillReturnResult(NewResult(0, 1))
+
+	if _, err := db.Exec("UPDATE users SET age = ? WHERE name = ?", 42, "jane"); err == nil {
+		t.Fatal("expected an error, since the name does not match")
+	}
+	if age != 0 {
+		t.Fatalf("expected nothing to be captured, but got %d", age)
+	}
+}
+
+func TestConvertCaptured(t *testing.T) {
+	if v, ok := convertCaptured[int](int64(42)); !ok || v != 42 {
+		t.Errorf("expected int64 to be converted to int, but got %v (%t)", v, ok)
+	}
+	if v, ok := convertCaptured[string]([]byte("john")); !ok || v != "john" {
+		t.Errorf("expected []byte to be converted to string, but got %q (%t)", v, ok)
+	}
+	if _, ok := convertCaptured[string](int64(65)); ok {
+		t.Error("expected int64 not to be converted to string")
+	}
+	if v, ok := convertCaptured[interface{}](nil); !ok || v != nil {
+		t.Errorf("expected nil to be captured into an interface, but got %v (%t)", v, ok)
+	}
+	if _, ok := convertCaptured[int64](nil); ok {
+		t.Error("expected nil not to be captured into int64")
+	}
+	if _, ok := convertCaptured[int64](3.7); ok {
+		t.Error("expected 3.7 not to be truncated into int64")
+	}
+	if v, ok := convertCaptured[int64](3.0); !ok || v != 3 {
+		t.Errorf("expected 3.0 to be converted to int64, but got %v (%t)", v, ok)
+	}
+	if _, ok := convertCaptured[int8](int64(300)); ok {
+		t.Error("expected 300 not to overflow into int8")
+	}
+}
+
+func TestCaptureNestedInAnyOfAndNot(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	var name string
+	var age int64
+	var other int64
+	mock.ExpectExec("UPDATE users").
+		WithArgs(AnyOf(Capture(&other), Capture(&name)), Not(AllOf(Capture(&age), Eq(0)))).
+		WillReturnResult(NewResult(0, 1))
+
+	if _, err := db.Exec("UPDATE users SET name = ?, age = ?", "john", 42); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if name != "john" || other != 0 {
+		t.Errorf("expected the matching branch of AnyOf to capture john, but got %q and %d", name, other)
+	}
+	if age != 42 {
+		t.Errorf("expected Not to forward the capture, but got %d", age)
+	}
+}
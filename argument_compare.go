@@ -0,0 +1,104 @@
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+)
+
+// ArgsComparison is the policy by which the arguments expected with WithArgs
+// are compared with actual ones, once converted to driver values.
+// Arguments like AnyArg or Eq are not affected, they match by themselves.
+type ArgsComparison uint8
+
+const (
+	// ArgsStrict requires the same type and value, the default.
+	ArgsStrict ArgsComparison = iota
+	// ArgsNumericLoose additionally treats numbers of any type as equal
+	// if they hold the same value, like int64(4) and float64(4), which
+	// is common for arguments loaded from JSON fixtures.
+	ArgsNumericLoose
+	// ArgsDriverNormalized additionally treats []byte and string as equal
+	// if they hold the same bytes, and times as equal if they are the same
+	// instant, no matter in which location.
+	ArgsDriverNormalized
+)
+
+// String returns string representation
+func (p ArgsComparison) String() string {
+	switch p {
+	case ArgsStrict:
+		return "strict"
+	case ArgsNumericLoose:
+		return "numeric-loose"
+	case ArgsDriverNormalized:
+		return "driver-normalized"
+	}
+	return fmt.Sprintf("ArgsComparison(%d)", uint8(p))
+}
+
+// ArgsComparisonOption allows to choose how expected arguments are
+// compared with actual ones. The default is ArgsStrict.
+func ArgsComparisonOption(policy ArgsComparison) func(*sqlmock) error {
+	return func(s *sqlmock) error {
+		if policy > ArgsDriverNormalized {
+			return fmt.Errorf("unknown arguments comparison policy: %s", policy)
+		}
+		s.argsComparison = policy
+		return nil
+	}
+}
+
+// equal tells whether the expected driver value equals the actual one.
+func (p ArgsComparison) equal(expected, actual driver.Value) bool {
+	if reflect.DeepEqual(expected, actual) {
+		return true
+	}
+	if p >= ArgsNumericLoose {
+		if _, _, _, ok := toNumber(expected); ok {
+			cmp, ok := compareValues(expected, actual)
+			return ok && cmp == 0
+		}
+	}
+	if p >= ArgsDriverNormalized {
+		if cmp, ok := compareValues(expected, actual); ok {
+			return cmp == 0
+		}
+		if e, ok := driverText(expected); ok {
+			a, ok := driverText(actual)
+			return ok && e == a
+		}
+	}
+	return false
+}
+
+// driverText returns the text of a string or []byte driver value.
+func driverText(v driver.Value) (string, bool) {
+	switch s := v.(type) {
+	case string:
+		return s, true
+	case []byte:
+		return string(s), true
+	}
+	return "", false
+}
+
+// compareArg compares the expected argument k with the actual one by the
+// policy of the mock. A near miss, which a looser policy would accept,
+// is explained in the error.
+func (e *queryBasedExpectation) compareArg(k int, expected, actual driver.Value) error {
+	var policy ArgsComparison
+	if e.mock != nil {
+		policy = e.mock.argsComparison
+	}
+	if policy.equal(expected, actual) {
+		return nil
+	}
+	err := fmt.Errorf("argument %d expected [%T - %+v] does not match actual [%T - %+v]", k, expected, expected, actual, actual)
+	for looser := policy + 1; looser <= ArgsDriverNormalized; looser++ {
+		if looser.equal(expected, actual) {
+			return fmt.Errorf("%s, they would be equal with %s arguments comparison, see ArgsComparisonOption", err, looser)
+		}
+	}
+	return err
+}
@@ -0,0 +1,64 @@
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+func TestArgsComparisonPolicies(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		expected, actual driver.Value
+		strict           bool
+		numericLoose     bool
+		driverNormalized bool
+	}{
+		{int64(4), int64(4), true, true, true},
+		{int64(4), float64(4), false, true, true},
+		{float64(4.5), int64(4), false, false, false},
+		{[]byte("john"), "john", false, false, true},
+		{"john", []byte("john"), false, false, true},
+		{now, now.UTC(), false, false, true},
+		{now, now.Add(time.Second), false, false, false},
+		{"4", int64(4), false, false, false},
+	}
+
+	for i, c := range cases {
+		for policy, expected := range map[ArgsComparison]bool{
+			ArgsStrict:           c.strict,
+			ArgsNumericLoose:     c.numericLoose,
+			ArgsDriverNormalized: c.driverNormalized,
+		} {
+			if equal := policy.equal(c.expected, c.actual); equal != expected {
+				t.Errorf("expected %t with %s comparison, but got %t at %d case", expected, policy, equal, i)
+			}
+		}
+	}
+}
+
+func TestArgsComparisonOption(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New(ArgsComparisonOption(ArgsNumericLoose))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE users").WithArgs(float64(4), "john").WillReturnResult(NewResult(0, 1))
+	mock.ExpectExec("UPDATE users").WithArgs(int64(4), "john").WillReturnResult(NewResult(0, 1))
+
+	if _, err := db.Exec("UPDATE users SET age = ? WHERE name = ?", 4, "john"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_, err = db.Exec("UPDATE users SET age = ? WHERE name = ?", 4, []byte("john"))
+	expected := "ExecQuery 'UPDATE users SET age = ? WHERE name = ?', arguments do not match: argument 1 expected [string - john] does not match actual [[]uint8 - [106 111 104 110]], they would be equal with driver-normalized arguments comparison, see ArgsComparisonOption"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected a near miss error, but got: %v", err)
+	}
+
+	if _, _, err := New(ArgsComparisonOption(ArgsComparison(9))); err == nil || err.Error() != "unknown arguments comparison policy: ArgsComparison(9)" {
+		t.Fatalf("expected an unknown policy error, but got: %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package sqlmock
 
 import (
 	"database/sql/driver"
+	"strings"
 	"testing"
 	"time"
 )
@@ -56,3 +57,73 @@ func TestByteSliceArgument(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestArgumentMatchers(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		arg   Argument
+		value driver.Value
+		match bool
+		desc  string
+	}{
+		{AnyArg(), nil, true, "any value"},
+		{Eq(5), int64(5), true, "equal to 5"},
+		{Eq(5), float64(5), true, "equal to 5"},
+		{Eq(int64(5)), float64(5.5), false, "equal to 5"},
+		{Eq("john"), "john", true, `equal to "john"`},
+		{Eq([]byte("john")), []byte("john"), true, `equal to "john"`},
+		{Eq(now), now.UTC(), true, "equal to " + now.Format(time.RFC3339Nano)},
+		{Regex("^jo"), "john", true, `matching regexp "^jo"`},
+		{Regex("^jo"), []byte("ajohn"), false, `matching regexp "^jo"`},
+		{Regex("^jo"), int64(1), false, `matching regexp "^jo"`},
+		{OfType(time.Time{}), now, true, "of type time.Time"},
+		{OfType(""), int64(1), false, "of type string"},
+		{Between(1, 10), int64(10), true, "between 1 and 10"},
+		{Between(1, 10), float64(10.1), false, "between 1 and 10"},
+		{Between(1, 10), "5", false, "between 1 and 10"},
+		{In(1, 2, "three"), int64(2), true, `one of [1, 2, "three"]`},
+		{In(1, 2, "three"), "four", false, `one of [1, 2, "three"]`},
+		{Not(Eq(1)), int64(2), true, "not equal to 1"},
+		{Nil(), nil, true, "nil"},
+		{NotNil(), nil, false, "not nil"},
+		{TimeWithin(now, time.Second), now.Add(-time.Second), true, "time within 1s of " + now.Format(time.RFC3339Nano)},
+		{TimeWithin(now, time.Second), now.Add(2 * time.Second), false, "time within 1s of " + now.Format(time.RFC3339Nano)},
+		{JSONEq(`{"a": 1, "b": [true]}`), []byte(`{"b":[true],"a":1}`), true, `JSON equal to {"a": 1, "b": [true]}`},
+		{JSONEq(`{"a": 1}`), `{"a": 2}`, false, `JSON equal to {"a": 1}`},
+		{BytesEq([]byte("abc")), "abc", true, `bytes equal to "abc"`},
+		{BytesEq([]byte("abc")), []byte("abd"), false, `bytes equal to "abc"`},
+		{AllOf(NotNil(), Between(1, 3)), int64(2), true, "all of (not nil, between 1 and 3)"},
+		{AnyOf(Nil(), Eq(3)), int64(2), false, "any of (nil, equal to 3)"},
+	}
+
+	for i, c := range cases {
+		if match := c.arg.Match(c.value); match != c.match {
+			t.Errorf("expected match %t, but got %t at %d case", c.match, match, i)
+		}
+		if desc := describeArgument(c.arg); desc != c.desc {
+			t.Errorf("expected description %q, but got %q at %d case", c.desc, desc, i)
+		}
+	}
+}
+
+func TestArgumentMatcherDescriptions(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	exec := mock.ExpectExec("UPDATE users").
+		WithArgs(Regex("^jo"), Between(1, 10)).
+		WillReturnResult(NewResult(0, 1))
+
+	if !strings.Contains(exec.String(), "0 - matching regexp \"^jo\"\n    1 - between 1 and 10") {
+		t.Errorf("expected matchers to be described, but got:\n%s", exec)
+	}
+
+	_, err = db.Exec("UPDATE users SET name = ? WHERE id = ?", "john", 11)
+	if err == nil || !strings.Contains(err.Error(), "matcher between 1 and 10 could not match 1 argument") {
+		t.Errorf("expected a mismatch described by the matcher, but got: %v", err)
+	}
+}
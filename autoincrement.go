@@ -0,0 +1,156 @@
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// autoIncrement generates insert ids for every table, like
+// AUTO_INCREMENT or SERIAL columns do, see AutoIncrementOption.
+type autoIncrement struct {
+	sync.Mutex
+	start int64
+	next  map[string]int64
+}
+
+var (
+	insertTableRe = regexp.MustCompile("(?is)^\\s*INSERT\\s+(?:IGNORE\\s+)?INTO\\s+([`\"\\[\\]\\w.]+)")
+	returningRe   = regexp.MustCompile("(?is)\\bRETURNING\\s+(.+?)\\s*;?\\s*$")
+	columnRe      = regexp.MustCompile("^[`\"\\[\\]\\w.]+$")
+)
+
+// AutoIncrementOption allows to generate the insert ids of INSERT
+// statements, instead of hand coding them in every expectation.
+// Every table has its own sequence, starting at start:
+//
+//   - a matched INSERT Exec which returns no Result, or a Result without
+//     last insert id like NewResult(0, 2), returns the next id of the
+//     table, and the sequence is advanced by the rows affected
+//   - a matched "INSERT ... RETURNING id" Query which returns no rows
+//     returns a single row with the next id in the returned column, or
+//     in the first of several returned columns, which are NULL, like
+//     "RETURNING id, created_at"; aliases are used as column names,
+//     but "RETURNING *" and expressions are not supported
+//
+// Use Sqlmock.ResetAutoIncrement to start over between tests.
+func AutoIncrementOption(start int64) func(*sqlmock) error {
+	return func(s *sqlmock) error {
+		if start < 1 {
+			return fmt.Errorf("auto increment must start at a positive id, but got %d", start)
+		}
+		s.autoIncrement = &autoIncrement{start: start}
+		return nil
+	}
+}
+
+// ResetAutoIncrement starts the sequences of all tables over,
+// it has no effect unless AutoIncrementOption is used.
+func (c *sqlmock) ResetAutoIncrement() {
+	if c.autoIncrement == nil {
+		return
+	}
+	c.autoIncrement.Lock()
+	c.autoIncrement.next = nil
+	c.autoIncrement.Unlock()
+}
+
+// take reserves n ids of the table and returns the first of them.
+func (a *autoIncrement) take(table string, n int64) int64 {
+	a.Lock()
+	defer a.Unlock()
+	if a.next == nil {
+		a.next = make(map[string]int64)
+	}
+	id, ok := a.next[table]
+	if !ok {
+		id = a.start
+	}
+	if n < 1 {
+		n = 1
+	}
+	a.next[table] = id + n
+	return id
+}
+
+// insertTable returns the unquoted name of the table
+// the query inserts into, if it is an INSERT statement.
+func insertTable(query string) (string, bool) {
+	m := insertTableRe.FindStringSubmatch(query)
+	if m == nil {
+		return "", false
+	}
+	return strings.ToLower(unquoteIdentifier(m[1])), true
+}
+
+// unquoteIdentifier removes the quotes around every part of a
+// qualified name, like `shop`.`orders`.
+func unquoteIdentifier(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(part, "`\"[]")
+	}
+	return strings.Join(parts, ".")
+}
+
+// result fills the last insert id of the result of an INSERT statement,
+// if it has none, or creates a result for a single inserted row.
+func (a *autoIncrement) result(query string, res driver.Result) driver.Result {
+	table, ok := insertTable(query)
+	if !ok {
+		return res
+	}
+	if res == nil {
+		return NewResult(a.take(table, 1), 1)
+	}
+	r, ok := res.(*result)
+	if !ok || r.err != nil || r.insertID != 0 {
+		return res
+	}
+	return NewResult(a.take(table, r.rowsAffected), r.rowsAffected)
+}
+
+// returning returns the rows of an "INSERT ... RETURNING columns"
+// statement, with the next id of the table in the first column.
+func (a *autoIncrement) returning(query string) (*Rows, bool) {
+	table, ok := insertTable(query)
+	if !ok {
+		return nil, false
+	}
+	m := returningRe.FindStringSubmatch(query)
+	if m == nil {
+		return nil, false
+	}
+	columns, ok := returningColumns(m[1])
+	if !ok {
+		return nil, false
+	}
+	row := make([]driver.Value, len(columns))
+	row[0] = a.take(table, 1)
+	return NewRows(columns).AddRow(row...), true
+}
+
+// returningColumns names the columns of a RETURNING clause, like
+// "id, created_at AS created", by their aliases, if they have one.
+func returningColumns(clause string) ([]string, bool) {
+	var columns []string
+	for _, item := range strings.Split(clause, ",") {
+		fields := strings.Fields(item)
+		if len(fields) == 3 && strings.EqualFold(fields[1], "AS") {
+			fields = []string{fields[0], fields[2]}
+		}
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, false
+		}
+		for _, field := range fields {
+			if !columnRe.MatchString(field) {
+				return nil, false
+			}
+		}
+		name := fields[len(fields)-1]
+		columns = append(columns, unquoteIdentifier(name[strings.LastIndex(name, ".")+1:]))
+	}
+	return columns, true
+}
@@ -0,0 +1,139 @@
+package sqlmock
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAutoIncrementExec(t *testing.T) {
+	db, mock, err := New(AutoIncrementOption(100))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO users").Times(2)
+	mock.ExpectExec("INSERT INTO `orders`").WillReturnResult(NewResult(0, 3))
+	mock.ExpectExec("INSERT INTO users").WillReturnResult(NewResult(7, 1))
+	mock.ExpectExec("INSERT INTO users")
+
+	expected := []int64{100, 101, 100, 7, 102}
+	queries := []string{"INSERT INTO users", "INSERT INTO users", "INSERT INTO `orders`", "INSERT INTO users", "INSERT INTO users"}
+	for i, query := range queries {
+		res, err := db.Exec(query)
+		if err != nil {
+			t.Fatalf("%s: error was not expected, but got: %s", query, err)
+		}
+		if id, _ := res.LastInsertId(); id != expected[i] {
+			t.Errorf("%s: expected insert id %d, but got %d", query, expected[i], id)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAutoIncrementReturning(t *testing.T) {
+	db, mock, err := New(AutoIncrementOption(1), QueryMatcherOption(QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	query := `INSERT INTO "users" (name) VALUES ($1) RETURNING "id"`
+	mock.ExpectQuery(query).WithArgs("john")
+	mock.ExpectQuery(query).WithArgs("jane")
+
+	for _, name := range []string{"john", "jane"} {
+		var id int64
+		if err := db.QueryRow(query, name).Scan(&id); err != nil {
+			t.Fatalf("error was not expected, but got: %s", err)
+		}
+		if name == "jane" && id != 2 {
+			t.Errorf("expected id 2, but got %d", id)
+		}
+	}
+
+	mock.ResetAutoIncrement()
+	mock.ExpectQuery(query).WithArgs("doe")
+	var id int64
+	if err := db.QueryRow(query, "doe").Scan(&id); err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	if id != 1 {
+		t.Errorf("expected id 1 after the reset, but got %d", id)
+	}
+}
+
+func TestAutoIncrementDisabled(t *testing.T) {
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO users")
+	if _, err := db.Exec("INSERT INTO users"); err == nil {
+		t.Error("expected an error for an exec without result")
+	}
+	mock.ResetAutoIncrement()
+}
+
+func TestAutoIncrementOptionInvalidStart(t *testing.T) {
+	if _, _, err := New(AutoIncrementOption(0)); err == nil {
+		t.Error("expected an error for an auto increment starting at 0")
+	}
+}
+
+func TestInsertTable(t *testing.T) {
+	for query, table := range map[string]string{
+		"INSERT INTO users (name) VALUES (?)": "users",
+		"insert ignore into `shop`.`Orders` ": "shop.orders",
+		"  INSERT INTO [items] VALUES (1)":    "items",
+	} {
+		if got, ok := insertTable(query); !ok || got != table {
+			t.Errorf("%s: expected table %q, but got %q", query, table, got)
+		}
+	}
+	if _, ok := insertTable("UPDATE users SET name = ?"); ok {
+		t.Error("expected no table for an UPDATE")
+	}
+}
+
+func TestReturningColumns(t *testing.T) {
+	a := &autoIncrement{start: 7}
+	for query, columns := range map[string][]string{
+		`INSERT INTO users (name) VALUES ($1) RETURNING id`:                             {"id"},
+		`INSERT INTO users (name) VALUES ($1) RETURNING "users"."id";`:                  {"id"},
+		`INSERT INTO users (name) VALUES ($1) RETURNING id, created_at`:                 {"id", "created_at"},
+		"INSERT INTO users (name) VALUES ($1)\nRETURNING id AS user_id, created_at ;\n": {"user_id", "created_at"},
+	} {
+		rows, ok := a.returning(query)
+		if !ok {
+			t.Errorf("%s: expected returned rows", query)
+			continue
+		}
+		if !reflect.DeepEqual(rows.cols, columns) {
+			t.Errorf("%s: expected columns %v, but got %v", query, columns, rows.cols)
+		}
+		if rows.rows[0][0] == nil {
+			t.Errorf("%s: expected an id in the first column", query)
+		}
+		for _, v := range rows.rows[0][1:] {
+			if v != nil {
+				t.Errorf("%s: expected NULL in the other columns, but got %v", query, v)
+			}
+		}
+	}
+
+	for _, query := range []string{
+		`INSERT INTO users (name) VALUES ($1) RETURNING *`,
+		`INSERT INTO users (name) VALUES ($1) RETURNING id + 1`,
+		`INSERT INTO users (name) VALUES ($1)`,
+	} {
+		if _, ok := a.returning(query); ok {
+			t.Errorf("%s: expected no returned rows", query)
+		}
+	}
+}
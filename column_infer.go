@@ -0,0 +1,151 @@
+package sqlmock
+
+import "reflect"
+
+// DialectProfile names the database types of column metadata,
+// which is inferred for rows created without a column definition.
+type DialectProfile struct {
+	Name string
+	// TypeNames maps the types of driver values, like int64
+	// or time.Time, to the database type names of the dialect.
+	TypeNames map[reflect.Type]string
+}
+
+var (
+	typeInt64   = reflect.TypeOf(int64(0))
+	typeFloat64 = reflect.TypeOf(float64(0))
+	typeBool    = reflect.TypeOf(false)
+	typeString  = reflect.TypeOf("")
+	typeBytes   = reflect.TypeOf([]byte(nil))
+	typeAny     = reflect.TypeOf((*interface{})(nil)).Elem()
+)
+
+// DialectGeneric is the default DialectProfile, with type names of standard SQL.
+var DialectGeneric = DialectProfile{
+	Name: "generic",
+	TypeNames: map[reflect.Type]string{
+		typeInt64:   "BIGINT",
+		typeFloat64: "DOUBLE",
+		typeBool:    "BOOLEAN",
+		typeString:  "VARCHAR",
+		typeBytes:   "BLOB",
+		timeType:    "TIMESTAMP",
+	},
+}
+
+// DialectMySQL is the DialectProfile with type names MySQL reports.
+var DialectMySQL = DialectProfile{
+	Name: "mysql",
+	TypeNames: map[reflect.Type]string{
+		typeInt64:   "BIGINT",
+		typeFloat64: "DOUBLE",
+		typeBool:    "TINYINT",
+		typeString:  "VARCHAR",
+		typeBytes:   "BLOB",
+		timeType:    "DATETIME",
+	},
+}
+
+// DialectPostgres is the DialectProfile with type names Postgres reports.
+var DialectPostgres = DialectProfile{
+	Name: "postgres",
+	TypeNames: map[reflect.Type]string{
+		typeInt64:   "INT8",
+		typeFloat64: "FLOAT8",
+		typeBool:    "BOOL",
+		typeString:  "TEXT",
+		typeBytes:   "BYTEA",
+		timeType:    "TIMESTAMPTZ",
+	},
+}
+
+// DialectSQLite is the DialectProfile with type names SQLite reports.
+var DialectSQLite = DialectProfile{
+	Name: "sqlite",
+	TypeNames: map[reflect.Type]string{
+		typeInt64:   "INTEGER",
+		typeFloat64: "REAL",
+		typeBool:    "BOOLEAN",
+		typeString:  "TEXT",
+		typeBytes:   "BLOB",
+		timeType:    "DATETIME",
+	},
+}
+
+// ColumnMetadataOption allows to choose the DialectProfile naming the
+// database types of column metadata, which is inferred for rows created
+// without a column definition. The default is DialectGeneric.
+func ColumnMetadataOption(profile DialectProfile) func(*sqlmock) error {
+	return func(s *sqlmock) error {
+		s.dialect = profile
+		return nil
+	}
+}
+
+// inferColumns describes the columns of rows by the values they hold:
+//
+//   - the scan type is the type of the values, float64 if integers and
+//     floats are mixed, or interface{} if they differ otherwise
+//   - a column is nullable if any of its values is nil
+//   - strings and bytes report the longest value as their length
+//
+// Rows generated on demand are not read, their columns are of unknown type.
+func inferColumns(r *Rows, profile DialectProfile) []*Column {
+	if profile.TypeNames == nil {
+		profile = DialectGeneric
+	}
+
+	columns := make([]*Column, len(r.cols))
+	for i, name := range r.cols {
+		var scanType reflect.Type
+		var nullable bool
+		var length int64
+		for _, row := range r.rows {
+			v := row[i]
+			if v == nil {
+				nullable = true
+				continue
+			}
+			scanType = mergeScanTypes(scanType, reflect.TypeOf(v))
+			switch value := v.(type) {
+			case string:
+				length = maxLength(length, len(value))
+			case []byte:
+				length = maxLength(length, len(value))
+			}
+		}
+
+		column := NewColumn(name)
+		if scanType == nil {
+			column.scanType = typeAny
+		} else {
+			column.scanType = scanType
+			column.dbType = profile.TypeNames[scanType]
+		}
+		if r.source == nil {
+			column.Nullable(nullable)
+		}
+		if scanType == typeString || scanType == typeBytes {
+			column.WithLength(length)
+		}
+		columns[i] = column
+	}
+	return columns
+}
+
+func mergeScanTypes(a, b reflect.Type) reflect.Type {
+	switch {
+	case a == nil || a == b:
+		return b
+	case (a == typeInt64 || a == typeFloat64) && (b == typeInt64 || b == typeFloat64):
+		return typeFloat64
+	}
+	return typeAny
+}
+
+func maxLength(length int64, n int) int64 {
+	if int64(n) > length {
+		return int64(n)
+	}
+	return length
+}
@@ -0,0 +1,85 @@
+// +build go1.8
+
+package sqlmock
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInferColumns(t *testing.T) {
+	rows := NewRows([]string{"id", "name", "score", "created", "data", "nothing", "mixed"}).
+		AddRow(1, "john", 1.5, time.Now(), []byte("ab"), nil, 1).
+		AddRow(2, nil, 2, time.Now(), []byte("abcd"), nil, "one")
+
+	cases := []struct {
+		scanType reflect.Type
+		dbType   string
+		nullable bool
+		length   int64
+		lengthOk bool
+	}{
+		{typeInt64, "INT8", false, 0, false},
+		{typeString, "TEXT", true, 4, true},
+		{typeFloat64, "FLOAT8", false, 0, false},
+		{timeType, "TIMESTAMPTZ", false, 0, false},
+		{typeBytes, "BYTEA", false, 4, true},
+		{typeAny, "", true, 0, false},
+		{typeAny, "", false, 0, false},
+	}
+
+	columns := inferColumns(rows, DialectPostgres)
+	for i, c := range cases {
+		column := columns[i]
+		nullable, nullableOk := column.IsNullable()
+		length, lengthOk := column.Length()
+		if column.ScanType() != c.scanType || column.DbType() != c.dbType || nullable != c.nullable || !nullableOk || length != c.length || lengthOk != c.lengthOk {
+			t.Errorf("unexpected metadata of column %q: %v, %q, nullable %t, length %d (%t)", column.Name(), column.ScanType(), column.DbType(), nullable, length, lengthOk)
+		}
+	}
+}
+
+func TestInferredColumnTypes(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New(ColumnMetadataOption(DialectMySQL))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT").WillReturnRows(
+		NewRows([]string{"id", "active"}).AddRow(1, true),
+		NewRowsWithColumnDefinition(NewColumn("name").OfType("CHAR", "")).AddRow("john"),
+	)
+
+	rows, err := db.Query("SELECT id, active FROM users; SELECT name FROM users")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if types[0].DatabaseTypeName() != "BIGINT" || types[0].ScanType() != typeInt64 {
+		t.Errorf("unexpected inferred type of id: %s, %v", types[0].DatabaseTypeName(), types[0].ScanType())
+	}
+	if types[1].DatabaseTypeName() != "TINYINT" || types[1].ScanType() != typeBool {
+		t.Errorf("unexpected inferred type of active: %s, %v", types[1].DatabaseTypeName(), types[1].ScanType())
+	}
+
+	for rows.Next() {
+	}
+	if !rows.NextResultSet() {
+		t.Fatal("expected a second result set")
+	}
+	types, err = rows.ColumnTypes()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if types[0].DatabaseTypeName() != "CHAR" {
+		t.Errorf("expected the defined column type, but got %s", types[0].DatabaseTypeName())
+	}
+}
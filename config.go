@@ -3,11 +3,14 @@ package sqlmock
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
 	"strings"
+
+	"github.com/panhongrainbow/go-sqlxmock/sqlerrors"
 )
 
 // config.go is primarily used to set the main path ❗️.
@@ -67,9 +70,21 @@ func GetGenuineLocation() (path string) {
 
 // ConfigSet can be used to set the basic response
 type ConfigSet struct {
-	QueryString string         `json:"qureyString"`
-	QueryArgs   []driver.Value `json:"queryArgs"`
-	ReturnRows  []ConfigRows   `json:"returnRows"`
+	QueryString    string         `json:"qureyString"`
+	QueryArgs      []driver.Value `json:"queryArgs"`
+	ReturnRows     []ConfigRows   `json:"returnRows"`
+	Matcher        string         `json:"matcher"`        // QueryMatcher of this query by name, see configMatchers.
+	AnyPlaceholder bool           `json:"anyPlaceholder"` // Treats ?, $1 and :name placeholders as equivalent.
+	ReturnError    *ConfigError   `json:"returnError"`    // Error the query fails with, instead of returning rows.
+}
+
+// ConfigError names an error of the sqlerrors package with the arguments
+// of its constructor, like {"dialect": "mysql", "error": "deadlock"} or
+// {"dialect": "mysql", "error": "duplicateKey", "args": ["john", "PRIMARY"]}.
+type ConfigError struct {
+	Dialect string   `json:"dialect"`
+	Name    string   `json:"error"`
+	Args    []string `json:"args"`
 }
 
 // ConfigRows can be used to set the corresponding database schema.
@@ -109,15 +124,45 @@ func LoadMockConfig(sqlMock Sqlmock, subPath, jsonFile string) (error error) {
 
 	// Iterate through the mock data and print the values.
 	for _, mock := range mockData {
+		// Using QuoteMeta simplifies the configuration file and makes the setup more convenient.
+		// A query with its own matcher is written the way that matcher expects instead.
+		queryString := regexp.QuoteMeta(mock.QueryString)
+		var matcher QueryMatcher
+		if mock.Matcher != "" {
+			var ok bool
+			if matcher, ok = configMatchers[mock.Matcher]; !ok {
+				return fmt.Errorf("unknown matcher %q for query %q in %s", mock.Matcher, mock.QueryString, mockFile)
+			}
+			queryString = mock.QueryString
+		}
+		if mock.AnyPlaceholder {
+			if matcher == nil {
+				queryString = anyPlaceholderPattern(mock.QueryString)
+			} else {
+				matcher = QueryMatcherAnyPlaceholder(matcher)
+			}
+		}
+
+		if mock.ReturnError != nil {
+			returnErr, ok := sqlerrors.Lookup(sqlerrors.Dialect(mock.ReturnError.Dialect), mock.ReturnError.Name, mock.ReturnError.Args...)
+			if !ok {
+				return fmt.Errorf("unknown error %q of dialect %q for query %q in %s", mock.ReturnError.Name, mock.ReturnError.Dialect, mock.QueryString, mockFile)
+			}
+			expected := sqlMock.ExpectQuery(queryString).WithArgs(convertNumbers(mock.QueryArgs)...).WillReturnError(returnErr)
+			if matcher != nil {
+				expected.MatchWith(matcher)
+			}
+		}
+
 		for _, returnRows := range mock.ReturnRows {
 			response := NewRows(returnRows.Columns)
 			for _, row := range returnRows.Rows {
 				response = response.AddRow(convertNumbers(row)...)
 			}
-			sqlMock.ExpectQuery(
-				// Using QuoteMeta simplifies the configuration file and makes the setup more convenient.
-				regexp.QuoteMeta(mock.QueryString),
-			).WithArgs(convertNumbers(mock.QueryArgs)...).WillReturnRows(response)
+			expected := sqlMock.ExpectQuery(queryString).WithArgs(convertNumbers(mock.QueryArgs)...).WillReturnRows(response)
+			if matcher != nil {
+				expected.MatchWith(matcher)
+			}
 		}
 	}
 
@@ -146,6 +191,13 @@ func LoadGenuineConfig(subPath, jsonFile string) (dbOpts DBOptions, err error) {
 
 // >>>>> >>>>> >>>>> >>>>> Using a helper function to assist the LoadMockConfig function.
 
+// configMatchers are the QueryMatchers which can be chosen by name in a ConfigSet.
+var configMatchers = map[string]QueryMatcher{
+	"regexp":     QueryMatcherRegexp,
+	"equal":      QueryMatcherEqual,
+	"normalized": QueryMatcherNormalized,
+}
+
 // convertNumbers is used to convert data in json.Number format to integers or decimals,
 // as json.NewDecoder returns data in json.Number format.
 func convertNumbers(data []driver.Value) []driver.Value {
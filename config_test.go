@@ -1,7 +1,10 @@
 package sqlmock
 
 import (
+	"errors"
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
+	"github.com/panhongrainbow/go-sqlxmock/sqlerrors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -67,3 +70,76 @@ func Test_Check_Config_Select(t *testing.T) {
 		index++
 	}
 }
+
+// Test_Check_Config_Matcher tests queries which choose their own matcher in the mock data.
+func Test_Check_Config_Matcher(t *testing.T) {
+	// Create a new SQL mock for testing.
+	sqlDB, sqlMock, err := New(QueryMatcherOption(QueryMatcherEqual))
+	require.NoError(t, err)
+	defer func() {
+		_ = sqlDB.Close()
+	}()
+
+	// Prepare SQL mock data.
+	SetMockLocationByManual("./mock")
+	err = LoadMockConfig(sqlMock, "/matcher", "select_matchers.json")
+	require.NoError(t, err)
+
+	// Each query is matched by the matcher of its configuration.
+	tests := []struct {
+		query string
+		arg   string
+		ID    int
+	}{
+		{"SELECT id, name FROM hotels WHERE city = ?", "New York", 1},
+		{"SELECT id, name FROM hotels WHERE city = ? ORDER BY rating", "Beijing", 3},
+		{`select "id", "name" from hotels where city = $1`, "Miami", 5},
+	}
+	for _, test := range tests {
+		var id int
+		var name string
+		err = sqlDB.QueryRow(test.query, test.arg).Scan(&id, &name)
+		require.NoError(t, err)
+		assert.Equal(t, test.ID, id)
+	}
+
+	require.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+// Test_Check_Config_Error tests queries which fail with an error of the sqlerrors package.
+func Test_Check_Config_Error(t *testing.T) {
+	// Create a new SQL mock for testing.
+	sqlDB, sqlMock, err := New()
+	require.NoError(t, err)
+	defer func() {
+		_ = sqlDB.Close()
+	}()
+
+	// Prepare SQL mock data.
+	SetMockLocationByManual("./mock")
+	err = LoadMockConfig(sqlMock, "/errors", "select_errors.json")
+	require.NoError(t, err)
+
+	// The MySQL error is the one of the driver.
+	_, err = sqlDB.Query("SELECT id FROM rooms WHERE hotel_id = ? FOR UPDATE", 1)
+	var mysqlErr *mysql.MySQLError
+	require.True(t, errors.As(err, &mysqlErr))
+	assert.Equal(t, uint16(1205), mysqlErr.Number)
+
+	// The Postgres error has its SQLSTATE code.
+	_, err = sqlDB.Query("SELECT id FROM rooms WHERE hotel_id = ? FOR UPDATE", 2)
+	var pgErr *sqlerrors.PostgresError
+	require.True(t, errors.As(err, &pgErr))
+	assert.Equal(t, "40P01", pgErr.SQLState())
+
+	// The arguments of the error are passed to its constructor.
+	_, err = sqlDB.Query("SELECT id FROM rooms WHERE hotel_id = ? FOR UPDATE", 3)
+	require.True(t, errors.As(err, &mysqlErr))
+	assert.Equal(t, "Duplicate entry '3' for key 'PRIMARY'", mysqlErr.Message)
+
+	require.NoError(t, sqlMock.ExpectationsWereMet())
+
+	// An unknown error is refused.
+	err = LoadMockConfig(sqlMock, "/errors", "unknown_error.json")
+	assert.EqualError(t, err, `unknown error "outOfMemory" of dialect "mysql" for query "SELECT id FROM rooms" in mock/errors/unknown_error.json`)
+}
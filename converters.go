@@ -0,0 +1,309 @@
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// valueConverter converts the values convert handles,
+// others like driver.DefaultParameterConverter does.
+type valueConverter struct {
+	convert func(v interface{}) (driver.Value, bool, error)
+}
+
+func (c valueConverter) ConvertValue(v interface{}) (driver.Value, error) {
+	if value, ok, err := c.convert(v); ok || err != nil {
+		return value, err
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+var (
+	// JSONConverter marshals maps, structs and slices other than []byte
+	// to JSON, like values of JSON columns are written. Structs which are
+	// a driver.Valuer or time.Time are converted as usual.
+	JSONConverter driver.ValueConverter = valueConverter{convertJSON}
+
+	// UUIDConverter converts UUID types, which are arrays of 16 bytes
+	// without a Value method, to their canonical string form.
+	UUIDConverter driver.ValueConverter = valueConverter{convertUUID}
+
+	// DecimalConverter converts the numbers of math/big to decimal strings,
+	// like values of DECIMAL and NUMERIC columns are written exactly.
+	DecimalConverter driver.ValueConverter = valueConverter{convertDecimal}
+
+	// PostgresArrayConverter converts slices and arrays other than []byte
+	// to the text form of Postgres arrays, like {1,2,3} or {"a","b"}.
+	PostgresArrayConverter driver.ValueConverter = valueConverter{convertPostgresArray}
+)
+
+// Converters combines ValueConverters, every value is converted by the
+// first of them converting it in a special way, or else like
+// driver.DefaultParameterConverter does. A converter other than the ones
+// of this package is used if it converts the value without an error.
+//
+//	db, mock, err := sqlmock.New(sqlmock.ValueConverterOption(
+//		sqlmock.Converters(sqlmock.UUIDConverter, sqlmock.JSONConverter),
+//	))
+//
+// The converter of the mock converts arguments as well as expected
+// arguments of WithArgs and the values of rows created by Sqlmock.NewRows.
+func Converters(converters ...driver.ValueConverter) driver.ValueConverter {
+	return valueConverter{func(v interface{}) (driver.Value, bool, error) {
+		for _, c := range converters {
+			if vc, ok := c.(valueConverter); ok {
+				if value, ok, err := vc.convert(v); ok || err != nil {
+					return value, ok, err
+				}
+				continue
+			}
+			if value, err := c.ConvertValue(v); err == nil {
+				return value, true, nil
+			}
+		}
+		return nil, false, nil
+	}}
+}
+
+// JSONValue returns v marshaled to JSON, for rows of JSON columns
+// or expected arguments. It panics if v can not be marshaled.
+func JSONValue(v interface{}) driver.Value {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Errorf("could not marshal %T to JSON: %s", v, err))
+	}
+	return b
+}
+
+var uuidRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// UUIDValue returns the canonical string form of a UUID, given as an
+// array of 16 bytes or as a string. It panics if v is not a UUID.
+func UUIDValue(v interface{}) driver.Value {
+	if s, ok := v.(string); ok {
+		if !uuidRe.MatchString(s) {
+			panic(fmt.Errorf("%q is not a UUID", s))
+		}
+		return strings.ToLower(s)
+	}
+	value, ok, _ := convertUUID(v)
+	if !ok {
+		panic(fmt.Errorf("%T is not a UUID", v))
+	}
+	return value
+}
+
+var decimalRe = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+
+// DecimalValue returns the decimal string s, for rows of DECIMAL
+// columns, which drivers return as text to keep them exact. It
+// panics if s is not a decimal number.
+func DecimalValue(s string) driver.Value {
+	if !decimalRe.MatchString(s) {
+		panic(fmt.Errorf("%q is not a decimal number", s))
+	}
+	return s
+}
+
+// PostgresArrayValue returns the text form of a Postgres array of the
+// values of a slice or array, like {1,2,3}. It panics if the values
+// can not be elements of an array.
+func PostgresArrayValue(v interface{}) driver.Value {
+	value, ok, err := convertPostgresArray(v)
+	if err != nil {
+		panic(err)
+	}
+	if !ok {
+		panic(fmt.Errorf("%T is not a slice or an array", v))
+	}
+	return value
+}
+
+func isValuer(v interface{}) bool {
+	_, ok := v.(driver.Valuer)
+	return ok
+}
+
+func convertJSON(v interface{}) (driver.Value, bool, error) {
+	if v == nil || isValuer(v) {
+		return nil, false, nil
+	}
+	rv := reflect.ValueOf(v)
+	switch derefType(rv.Type()).Kind() {
+	case reflect.Map, reflect.Struct, reflect.Slice, reflect.Array:
+	default:
+		return nil, false, nil
+	}
+	if _, ok := v.([]byte); ok || derefType(rv.Type()) == timeType {
+		return nil, false, nil
+	}
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil, true, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not marshal %T to JSON: %s", v, err)
+	}
+	return b, true, nil
+}
+
+func convertUUID(v interface{}) (driver.Value, bool, error) {
+	if v == nil || isValuer(v) {
+		return nil, false, nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Array || rv.Len() != 16 || rv.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false, nil
+	}
+	b := make([]byte, 16)
+	for i := range b {
+		b[i] = byte(rv.Index(i).Uint())
+	}
+	s := hex.EncodeToString(b)
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:], true, nil
+}
+
+func convertDecimal(v interface{}) (driver.Value, bool, error) {
+	switch value := v.(type) {
+	case *big.Int:
+		if value == nil {
+			return nil, true, nil
+		}
+		return value.String(), true, nil
+	case big.Int:
+		return value.String(), true, nil
+	case *big.Float:
+		if value == nil {
+			return nil, true, nil
+		}
+		return value.Text('f', -1), true, nil
+	case big.Float:
+		return value.Text('f', -1), true, nil
+	case *big.Rat:
+		if value == nil {
+			return nil, true, nil
+		}
+		if value.IsInt() {
+			return value.Num().String(), true, nil
+		}
+		if prec, exact := decimalPrec(value); exact {
+			return value.FloatString(prec), true, nil
+		}
+		return nil, false, fmt.Errorf("%s has no exact decimal representation", value)
+	}
+	return nil, false, nil
+}
+
+// decimalPrec returns the number of decimal digits a fraction needs,
+// it is exact only if the denominator has no prime factors but 2 and 5.
+func decimalPrec(r *big.Rat) (int, bool) {
+	d := new(big.Int).Set(r.Denom())
+	var twos, fives int
+	two, five, mod := big.NewInt(2), big.NewInt(5), new(big.Int)
+	for d.Sign() > 0 && mod.Mod(d, two).Sign() == 0 {
+		d.Quo(d, two)
+		twos++
+	}
+	for d.Sign() > 0 && mod.Mod(d, five).Sign() == 0 {
+		d.Quo(d, five)
+		fives++
+	}
+	if twos < fives {
+		twos = fives
+	}
+	return twos, d.IsInt64() && d.Int64() == 1
+}
+
+func convertPostgresArray(v interface{}) (driver.Value, bool, error) {
+	if v == nil || isValuer(v) {
+		return nil, false, nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false, nil
+	}
+	if _, ok := v.([]byte); ok || (rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8) {
+		return nil, false, nil
+	}
+	if rv.Kind() == reflect.Slice && rv.IsNil() {
+		return nil, true, nil
+	}
+	var sb strings.Builder
+	if err := writePostgresArray(&sb, rv); err != nil {
+		return nil, false, err
+	}
+	return sb.String(), true, nil
+}
+
+// writePostgresArray writes the elements of a slice or array, nested
+// ones as arrays of another dimension, like pq.Array does.
+func writePostgresArray(sb *strings.Builder, rv reflect.Value) error {
+	sb.WriteByte('{')
+	for i := 0; i < rv.Len(); i++ {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		elem := rv.Index(i)
+		for elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface {
+			if elem.IsNil() {
+				break
+			}
+			elem = elem.Elem()
+		}
+		if err := writePostgresElement(sb, elem); err != nil {
+			return err
+		}
+	}
+	sb.WriteByte('}')
+	return nil
+}
+
+func writePostgresElement(sb *strings.Builder, elem reflect.Value) error {
+	switch elem.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		sb.WriteString("NULL")
+		return nil
+	case reflect.Slice, reflect.Array:
+		if elem.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, elem.Len())
+			reflect.Copy(reflect.ValueOf(b), elem)
+			sb.WriteString(`"\\x` + hex.EncodeToString(b) + `"`)
+			return nil
+		}
+		return writePostgresArray(sb, elem)
+	case reflect.Bool:
+		if elem.Bool() {
+			sb.WriteByte('t')
+		} else {
+			sb.WriteByte('f')
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sb.WriteString(strconv.FormatInt(elem.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		sb.WriteString(strconv.FormatUint(elem.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		sb.WriteString(strconv.FormatFloat(elem.Float(), 'g', -1, elem.Type().Bits()))
+	case reflect.String:
+		sb.WriteString(quotePostgresElement(elem.String()))
+	default:
+		if t, ok := elem.Interface().(time.Time); ok {
+			sb.WriteString(quotePostgresElement(t.Format(time.RFC3339Nano)))
+			return nil
+		}
+		return fmt.Errorf("%s can not be an element of a Postgres array", elem.Type())
+	}
+	return nil
+}
+
+// quotePostgresElement quotes a string element, escaping quotes and backslashes.
+func quotePostgresElement(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
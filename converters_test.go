@@ -0,0 +1,151 @@
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+type testUUID [16]byte
+
+type testSettings struct {
+	Theme string `json:"theme"`
+	Beta  bool   `json:"beta"`
+}
+
+func TestConverters(t *testing.T) {
+	id := testUUID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	converter := Converters(UUIDConverter, DecimalConverter, JSONConverter)
+
+	tests := []struct {
+		value    interface{}
+		expected driver.Value
+	}{
+		{id, "123e4567-e89b-12d3-a456-426614174000"},
+		{map[string]int{"b": 2, "a": 1}, []byte(`{"a":1,"b":2}`)},
+		{testSettings{Theme: "dark"}, []byte(`{"theme":"dark","beta":false}`)},
+		{&testSettings{Beta: true}, []byte(`{"theme":"","beta":true}`)},
+		{[]string{"x"}, []byte(`["x"]`)},
+		{big.NewInt(42), "42"},
+		{big.NewRat(1234, 100), "12.34"},
+		{new(big.Float).SetFloat64(0.5), "0.5"},
+		{[]byte("raw"), []byte("raw")},
+		{uint8(3), int64(3)},
+		{"text", "text"},
+		{nil, nil},
+	}
+	for _, test := range tests {
+		value, err := converter.ConvertValue(test.value)
+		if err != nil {
+			t.Errorf("%#v: error was not expected, but got: %s", test.value, err)
+			continue
+		}
+		if !reflect.DeepEqual(value, test.expected) {
+			t.Errorf("%#v: expected %#v, but got %#v", test.value, test.expected, value)
+		}
+	}
+
+	if _, err := DecimalConverter.ConvertValue(big.NewRat(1, 3)); err == nil {
+		t.Error("expected an error converting a fraction with no exact decimal representation")
+	}
+	if _, err := JSONConverter.ConvertValue(map[string]interface{}{"f": func() {}}); err == nil {
+		t.Error("expected an error converting a value which can not be marshaled")
+	}
+}
+
+func TestPostgresArrayConverter(t *testing.T) {
+	one := 1
+	tests := []struct {
+		value    interface{}
+		expected driver.Value
+	}{
+		{[]int{1, 2, 3}, "{1,2,3}"},
+		{[]int64{}, "{}"},
+		{[]string{"a", `b"c`, `d\e`, ""}, `{"a","b\"c","d\\e",""}`},
+		{[]bool{true, false}, "{t,f}"},
+		{[]float64{1.5, 2}, "{1.5,2}"},
+		{[][]int{{1, 2}, {3, 4}}, "{{1,2},{3,4}}"},
+		{[]*int{&one, nil}, "{1,NULL}"},
+		{[][]byte{[]byte("hi")}, `{"\\x6869"}`},
+		{[2]uint{4, 5}, "{4,5}"},
+		{[]string(nil), nil},
+	}
+	for _, test := range tests {
+		value, err := PostgresArrayConverter.ConvertValue(test.value)
+		if err != nil {
+			t.Errorf("%#v: error was not expected, but got: %s", test.value, err)
+			continue
+		}
+		if !reflect.DeepEqual(value, test.expected) {
+			t.Errorf("%#v: expected %#v, but got %#v", test.value, test.expected, value)
+		}
+	}
+
+	if _, err := PostgresArrayConverter.ConvertValue([]map[string]int{{}}); err == nil {
+		t.Error("expected an error converting a slice of maps")
+	}
+}
+
+func TestConvertersWithArgsAndRows(t *testing.T) {
+	id := testUUID{0xaa, 15: 0x01}
+	settings := testSettings{Theme: "dark"}
+
+	db, mock, err := New(ValueConverterOption(Converters(UUIDConverter, PostgresArrayConverter, JSONConverter)))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE users").
+		WithArgs(settings, []string{"admin", "dev"}, id).
+		WillReturnResult(NewResult(0, 1))
+	mock.ExpectQuery("SELECT").
+		WithArgs(UUIDValue("AA000000-0000-0000-0000-000000000001")).
+		WillReturnRows(mock.NewRows([]string{"id", "settings", "tags", "balance"}).
+			AddRow(id, JSONValue(settings), PostgresArrayValue([]string{"admin"}), DecimalValue("10.25")))
+
+	if _, err := db.Exec("UPDATE users", settings, []string{"admin", "dev"}, id); err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+
+	var uuid, balance string
+	var raw, tags []byte
+	if err := db.QueryRow("SELECT", id).Scan(&uuid, &raw, &tags, &balance); err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	if uuid != "aa000000-0000-0000-0000-000000000001" {
+		t.Errorf("unexpected uuid %q", uuid)
+	}
+	if string(raw) != `{"theme":"dark","beta":false}` {
+		t.Errorf("unexpected settings %s", raw)
+	}
+	if string(tags) != `{"admin"}` {
+		t.Errorf("unexpected tags %s", tags)
+	}
+	if balance != "10.25" {
+		t.Errorf("unexpected balance %q", balance)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestConverterValuesPanic(t *testing.T) {
+	for name, f := range map[string]func(){
+		"UUIDValue":          func() { UUIDValue("not-a-uuid") },
+		"UUIDValue type":     func() { UUIDValue(42) },
+		"DecimalValue":       func() { DecimalValue("1,5") },
+		"PostgresArrayValue": func() { PostgresArrayValue("text") },
+		"JSONValue":          func() { JSONValue(make(chan int)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected a panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,229 @@
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// DriverProfile emulates how a real driver types values and what it
+// supports, so that scanning code meets the values it gets in production
+// rather than the values as they were given to the mock. It is chosen
+// with DriverProfileOption.
+type DriverProfile struct {
+	Name string
+	// Dialect names the database types of column metadata.
+	Dialect DialectProfile
+	// ConvertRowValue converts a value of a row to the value the driver
+	// returns for it, values are returned as given if it is nil.
+	ConvertRowValue func(v driver.Value) driver.Value
+	// ValueConverter converts arguments like the driver does, and refuses
+	// the ones it does not support, driver.DefaultParameterConverter if nil.
+	ValueConverter driver.ValueConverter
+	// LastInsertID tells whether results support LastInsertId.
+	LastInsertID bool
+	// MultipleResultSets tells whether rows may have several result sets.
+	MultipleResultSets bool
+}
+
+// DriverMySQLText emulates go-sql-driver/mysql for queries without
+// arguments, or with interpolateParams, which MySQL answers with its
+// text protocol: every value is []byte, times too unless parseTime.
+var DriverMySQLText = DriverProfile{
+	Name:               "mysql text protocol",
+	Dialect:            DialectMySQL,
+	ConvertRowValue:    mysqlTextValue(false),
+	ValueConverter:     mysqlConverter{},
+	LastInsertID:       true,
+	MultipleResultSets: true,
+}
+
+// DriverMySQLTextParseTime is DriverMySQLText with parseTime=true,
+// which returns dates and times as time.Time.
+var DriverMySQLTextParseTime = DriverProfile{
+	Name:               "mysql text protocol with parseTime",
+	Dialect:            DialectMySQL,
+	ConvertRowValue:    mysqlTextValue(true),
+	ValueConverter:     mysqlConverter{},
+	LastInsertID:       true,
+	MultipleResultSets: true,
+}
+
+// DriverMySQLBinary emulates go-sql-driver/mysql for prepared statements,
+// which MySQL answers with its binary protocol: integers are int64, but
+// strings are []byte, booleans are integers and times are []byte.
+var DriverMySQLBinary = DriverProfile{
+	Name:               "mysql binary protocol",
+	Dialect:            DialectMySQL,
+	ConvertRowValue:    mysqlBinaryValue(false),
+	ValueConverter:     mysqlConverter{},
+	LastInsertID:       true,
+	MultipleResultSets: true,
+}
+
+// DriverMySQLBinaryParseTime is DriverMySQLBinary with parseTime=true,
+// which returns dates and times as time.Time.
+var DriverMySQLBinaryParseTime = DriverProfile{
+	Name:               "mysql binary protocol with parseTime",
+	Dialect:            DialectMySQL,
+	ConvertRowValue:    mysqlBinaryValue(true),
+	ValueConverter:     mysqlConverter{},
+	LastInsertID:       true,
+	MultipleResultSets: true,
+}
+
+// DriverPostgres emulates the Postgres drivers, which return values typed
+// by their columns, but do not support LastInsertId, an id is returned
+// with "INSERT ... RETURNING id" instead.
+var DriverPostgres = DriverProfile{
+	Name:               "postgres",
+	Dialect:            DialectPostgres,
+	LastInsertID:       false,
+	MultipleResultSets: true,
+}
+
+// DriverSQLite emulates mattn/go-sqlite3, which returns booleans as the
+// integers SQLite stores and returns a single result set only.
+var DriverSQLite = DriverProfile{
+	Name:               "sqlite",
+	Dialect:            DialectSQLite,
+	ConvertRowValue:    sqliteValue,
+	LastInsertID:       true,
+	MultipleResultSets: false,
+}
+
+// DriverProfileOption allows to emulate a real driver with a DriverProfile:
+// values of rows are converted like the driver returns them, arguments are
+// converted with its ValueConverter and operations it does not support fail.
+// It replaces the ValueConverter and the column metadata dialect of the mock.
+func DriverProfileOption(profile DriverProfile) func(*sqlmock) error {
+	return func(s *sqlmock) error {
+		s.driverProfile = &profile
+		s.converter = profile.ValueConverter
+		if s.converter == nil {
+			s.converter = driver.DefaultParameterConverter
+		}
+		if profile.Dialect.TypeNames != nil {
+			s.dialect = profile.Dialect
+		}
+		return nil
+	}
+}
+
+// MySQL formats times like this, with the fraction of its precision
+const mysqlTimeLayout = "2006-01-02 15:04:05.999999"
+
+func mysqlTextValue(parseTime bool) func(driver.Value) driver.Value {
+	return func(v driver.Value) driver.Value {
+		switch value := v.(type) {
+		case int64:
+			return strconv.AppendInt(nil, value, 10)
+		case float64:
+			return strconv.AppendFloat(nil, value, 'g', -1, 64)
+		case bool:
+			if value {
+				return []byte("1")
+			}
+			return []byte("0")
+		case string:
+			return []byte(value)
+		case time.Time:
+			if parseTime {
+				return value
+			}
+			return []byte(value.Format(mysqlTimeLayout))
+		}
+		return v
+	}
+}
+
+func mysqlBinaryValue(parseTime bool) func(driver.Value) driver.Value {
+	return func(v driver.Value) driver.Value {
+		switch value := v.(type) {
+		case bool:
+			if value {
+				return int64(1)
+			}
+			return int64(0)
+		case string:
+			return []byte(value)
+		case time.Time:
+			if parseTime {
+				return value
+			}
+			return []byte(value.Format(mysqlTimeLayout))
+		}
+		return v
+	}
+}
+
+func sqliteValue(v driver.Value) driver.Value {
+	if value, ok := v.(bool); ok {
+		if value {
+			return int64(1)
+		}
+		return int64(0)
+	}
+	return v
+}
+
+// mysqlConverter converts arguments like go-sql-driver/mysql does,
+// which also supports unsigned integers with the high bit set.
+type mysqlConverter struct{}
+
+func (mysqlConverter) ConvertValue(v interface{}) (driver.Value, error) {
+	if _, ok := v.(driver.Valuer); !ok {
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if u := rv.Uint(); u >= 1<<63 {
+				return u, nil
+			}
+		}
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+// convertRowValue converts a value of a row with the driver profile.
+func (c *sqlmock) convertRowValue(v driver.Value) driver.Value {
+	if c == nil || c.driverProfile == nil || c.driverProfile.ConvertRowValue == nil || v == nil {
+		return v
+	}
+	return c.driverProfile.ConvertRowValue(v)
+}
+
+// driverResult restricts a result to what the driver profile supports.
+func (c *sqlmock) driverResult(res driver.Result) driver.Result {
+	if c.driverProfile == nil || c.driverProfile.LastInsertID {
+		return res
+	}
+	return noLastInsertID{Result: res, driver: c.driverProfile.Name}
+}
+
+// noLastInsertID is a result of a driver not supporting LastInsertId.
+type noLastInsertID struct {
+	driver.Result
+	driver string
+}
+
+func (r noLastInsertID) LastInsertId() (int64, error) {
+	return 0, fmt.Errorf("LastInsertId is not supported by the %s driver", r.driver)
+}
+
+// checkNextResultSet fails if the driver profile does not support multiple result sets.
+func (c *sqlmock) checkNextResultSet() error {
+	if c == nil || c.driverProfile == nil || c.driverProfile.MultipleResultSets {
+		return nil
+	}
+	return fmt.Errorf("multiple result sets are not supported by the %s driver", c.driverProfile.Name)
+}
+
+// mock returns the mock which returned the rows, nil if there is none.
+func (rs *rowSets) mock() *sqlmock {
+	if rs.ex == nil {
+		return nil
+	}
+	return rs.ex.mock
+}
@@ -0,0 +1,125 @@
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDriverProfileRowValues(t *testing.T) {
+	created := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	values := []driver.Value{int64(7), 9.5, true, "john", created, nil}
+
+	tests := []struct {
+		profile  DriverProfile
+		expected []interface{}
+	}{
+		{DriverMySQLText, []interface{}{[]byte("7"), []byte("9.5"), []byte("1"), []byte("john"), []byte("2023-01-02 03:04:05"), nil}},
+		{DriverMySQLTextParseTime, []interface{}{[]byte("7"), []byte("9.5"), []byte("1"), []byte("john"), created, nil}},
+		{DriverMySQLBinary, []interface{}{int64(7), 9.5, int64(1), []byte("john"), []byte("2023-01-02 03:04:05"), nil}},
+		{DriverMySQLBinaryParseTime, []interface{}{int64(7), 9.5, int64(1), []byte("john"), created, nil}},
+		{DriverPostgres, []interface{}{int64(7), 9.5, true, "john", created, nil}},
+		{DriverSQLite, []interface{}{int64(7), 9.5, int64(1), "john", created, nil}},
+	}
+	for _, test := range tests {
+		db, mock, err := New(DriverProfileOption(test.profile))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectQuery("SELECT").WillReturnRows(NewRows([]string{"id", "price", "active", "name", "created", "deleted"}).AddRow(values...))
+
+		row := make([]interface{}, len(values))
+		dest := make([]interface{}, len(values))
+		for i := range row {
+			dest[i] = &row[i]
+		}
+		if err := db.QueryRow("SELECT").Scan(dest...); err != nil {
+			t.Fatalf("%s: error was not expected, but got: %s", test.profile.Name, err)
+		}
+		if !reflect.DeepEqual(row, test.expected) {
+			t.Errorf("%s: expected values %#v, but got %#v", test.profile.Name, test.expected, row)
+		}
+		db.Close()
+	}
+}
+
+func TestDriverProfileScanTextIntoInt(t *testing.T) {
+	db, mock, err := New(DriverProfileOption(DriverMySQLText))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT").WillReturnRows(NewRows([]string{"created"}).AddRow(time.Now()))
+	var created time.Time
+	err = db.QueryRow("SELECT").Scan(&created)
+	if err == nil || !strings.Contains(err.Error(), "unsupported Scan, storing driver.Value type []uint8 into type *time.Time") {
+		t.Errorf("expected scanning a time without parseTime to fail, but got: %v", err)
+	}
+}
+
+func TestDriverProfileLastInsertID(t *testing.T) {
+	db, mock, err := New(DriverProfileOption(DriverPostgres))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO users").WillReturnResult(NewResult(1, 1))
+	res, err := db.Exec("INSERT INTO users")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	if _, err := res.LastInsertId(); err == nil || err.Error() != "LastInsertId is not supported by the postgres driver" {
+		t.Errorf("expected LastInsertId to fail, but got: %v", err)
+	}
+	if affected, err := res.RowsAffected(); err != nil || affected != 1 {
+		t.Errorf("expected 1 row affected, but got %d and %v", affected, err)
+	}
+}
+
+func TestDriverProfileMultipleResultSets(t *testing.T) {
+	db, mock, err := New(DriverProfileOption(DriverSQLite))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT").WillReturnRows(NewRows([]string{"id"}).AddRow(1), NewRows([]string{"id"}).AddRow(2))
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+	}
+	if rows.NextResultSet() {
+		t.Error("expected no next result set")
+	}
+	if err := rows.Err(); err == nil || err.Error() != "multiple result sets are not supported by the sqlite driver" {
+		t.Errorf("expected an error for the next result set, but got: %v", err)
+	}
+}
+
+func TestDriverProfileArguments(t *testing.T) {
+	for _, test := range []struct {
+		profile DriverProfile
+		ok      bool
+	}{
+		{DriverMySQLBinary, true},
+		{DriverPostgres, false},
+	} {
+		db, mock, err := New(DriverProfileOption(test.profile))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		mock.ExpectExec("UPDATE counters").WithArgs(uint64(1<<63 + 1)).WillReturnResult(NewResult(0, 1))
+		_, err = db.Exec("UPDATE counters SET value = ?", uint64(1<<63+1))
+		if (err == nil) != test.ok {
+			t.Errorf("%s: unexpected result for an uint64 with the high bit set: %v", test.profile.Name, err)
+		}
+		db.Close()
+	}
+}
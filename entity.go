@@ -41,7 +41,7 @@ func NewMocker(mOpts MockerOptions) (mocker *Mocker, err error) {
 		}
 	} else {
 		// Create a new SQL mock for testing.
-		mocker.db, mocker.sqlMock, err = New()
+		mocker.db, mocker.sqlMock, err = New(mOpts.Mock.SqlmockOptions...)
 
 		// Prepare SQL mock data.
 		for i := 0; i < len(mOpts.Mock.ConfigFile); i++ {
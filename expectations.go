@@ -126,9 +126,13 @@ func (e *ExpectedRollback) String() string {
 type ExpectedQuery struct {
 	queryBasedExpectation
 	rows             driver.Rows
+	status           driver.Result
 	delay            time.Duration
 	rowsMustBeClosed bool
 	rowsWereClosed   bool
+	rowsMustBeRead   bool
+	rowsUnread       string // describes the result set not read to the end, once closed
+	tx               int    // transaction the rows were queried in, 0 if none
 }
 
 // WithArgs will match given expected args to actual database query arguments.
@@ -139,12 +143,29 @@ func (e *ExpectedQuery) WithArgs(args ...driver.Value) *ExpectedQuery {
 	return e
 }
 
+// MatchWith allows to match the SQL of this expectation with
+// the given QueryMatcher, instead of the one the mock uses.
+func (e *ExpectedQuery) MatchWith(matcher QueryMatcher) *ExpectedQuery {
+	e.mock.matchWith(e, &e.sqlMatcher, e.expectSQL, e.inListsMatcher(matcher))
+	return e
+}
+
 // RowsWillBeClosed expects this query rows to be closed.
 func (e *ExpectedQuery) RowsWillBeClosed() *ExpectedQuery {
 	e.rowsMustBeClosed = true
 	return e
 }
 
+// RowsMustBeFullyRead expects every row of every result set of this
+// query rows to be read before the rows are closed. That catches code
+// reading the first row of many only, while QueryRow reading the only
+// row of a result set meets it.
+// See RowsMustBeFullyReadOption to expect it of every query.
+func (e *ExpectedQuery) RowsMustBeFullyRead() *ExpectedQuery {
+	e.rowsMustBeRead = true
+	return e
+}
+
 // WillReturnError allows to set an error for expected database query
 func (e *ExpectedQuery) WillReturnError(err error) *ExpectedQuery {
 	e.err = err
@@ -162,6 +183,12 @@ func (e *ExpectedQuery) WillDelayFor(duration time.Duration) *ExpectedQuery {
 func (e *ExpectedQuery) String() string {
 	msg := "ExpectedQuery => expecting Query, QueryContext or QueryRow which:"
 	msg += "\n  - matches sql: '" + e.expectSQL + "'"
+	if e.namedSQL != "" {
+		msg += "\n  - is compiled from named sql: '" + e.namedSQL + "'"
+	}
+	if e.expandIn {
+		msg += "\n  - is expanded by sqlx.In to IN lists of " + e.inLists + " values"
+	}
 
 	if len(e.args) == 0 {
 		msg += "\n  - is without arguments"
@@ -173,6 +200,10 @@ func (e *ExpectedQuery) String() string {
 		msg = strings.TrimSpace(msg)
 	}
 
+	for _, out := range e.outs {
+		msg += fmt.Sprintf("\n  - %s", out)
+	}
+
 	if e.rows != nil {
 		msg += fmt.Sprintf("\n  - %s", e.rows)
 	}
@@ -188,8 +219,12 @@ func (e *ExpectedQuery) String() string {
 // Returned by *Sqlmock.ExpectExec.
 type ExpectedExec struct {
 	queryBasedExpectation
-	result driver.Result
-	delay  time.Duration
+	result   driver.Result
+	results  []driver.Result // returned in sequence, see WillReturnResults
+	callErrs map[int]error   // errors of single calls, see WillReturnErrorAt
+	times    int             // expected number of calls, see Times
+	calls    int
+	delay    time.Duration
 }
 
 // WithArgs will match given expected args to actual database exec operation arguments.
@@ -200,6 +235,13 @@ func (e *ExpectedExec) WithArgs(args ...driver.Value) *ExpectedExec {
 	return e
 }
 
+// MatchWith allows to match the SQL of this expectation with
+// the given QueryMatcher, instead of the one the mock uses.
+func (e *ExpectedExec) MatchWith(matcher QueryMatcher) *ExpectedExec {
+	e.mock.matchWith(e, &e.sqlMatcher, e.expectSQL, e.inListsMatcher(matcher))
+	return e
+}
+
 // WillReturnError allows to set an error for expected database exec action
 func (e *ExpectedExec) WillReturnError(err error) *ExpectedExec {
 	e.err = err
@@ -217,6 +259,12 @@ func (e *ExpectedExec) WillDelayFor(duration time.Duration) *ExpectedExec {
 func (e *ExpectedExec) String() string {
 	msg := "ExpectedExec => expecting Exec or ExecContext which:"
 	msg += "\n  - matches sql: '" + e.expectSQL + "'"
+	if e.namedSQL != "" {
+		msg += "\n  - is compiled from named sql: '" + e.namedSQL + "'"
+	}
+	if e.expandIn {
+		msg += "\n  - is expanded by sqlx.In to IN lists of " + e.inLists + " values"
+	}
 
 	if len(e.args) == 0 {
 		msg += "\n  - is without arguments"
@@ -229,7 +277,13 @@ func (e *ExpectedExec) String() string {
 		msg += strings.Join(margs, "\n")
 	}
 
-	if e.result != nil {
+	for _, out := range e.outs {
+		msg += fmt.Sprintf("\n  - %s", out)
+	}
+
+	msg += e.sequenceString()
+
+	if e.result != nil && len(e.results) == 0 {
 		res, _ := e.result.(*result)
 		msg += "\n  - should return Result having:"
 		msg += fmt.Sprintf("\n      LastInsertId: %d", res.insertID)
@@ -261,11 +315,17 @@ type ExpectedPrepare struct {
 	commonExpectation
 	mock         *sqlmock
 	expectSQL    string
+	namedSQL     string // set, when expectSQL was compiled from named SQL
+	sqlMatcher   sqlMatcher
 	statement    driver.Stmt
 	closeErr     error
 	mustBeClosed bool
 	wasClosed    bool
 	delay        time.Duration
+	numInput     int  // declared by WithNumInput
+	hasNumInput  bool // otherwise NumInput is -1 or counts the placeholders
+	mustExecute  int  // expected executions, see WillBeExecuted
+	executions   int  // executions of the prepared statement
 }
 
 // WillReturnError allows to set an error for the expected *sql.DB.Prepare or *sql.Tx.Prepare action.
@@ -274,6 +334,15 @@ func (e *ExpectedPrepare) WillReturnError(err error) *ExpectedPrepare {
 	return e
 }
 
+// MatchWith allows to match the SQL of this expectation with
+// the given QueryMatcher, instead of the one the mock uses.
+// Query and Exec expectations on this prepared statement
+// inherit the QueryMatcher, if they are expected afterwards.
+func (e *ExpectedPrepare) MatchWith(matcher QueryMatcher) *ExpectedPrepare {
+	e.mock.matchWith(e, &e.sqlMatcher, e.expectSQL, matcher)
+	return e
+}
+
 // WillReturnCloseError allows to set an error for this prepared statement Close action
 func (e *ExpectedPrepare) WillReturnCloseError(err error) *ExpectedPrepare {
 	e.closeErr = err
@@ -294,13 +363,34 @@ func (e *ExpectedPrepare) WillBeClosed() *ExpectedPrepare {
 	return e
 }
 
+// WithNumInput declares the number of arguments the prepared statement
+// takes, which database/sql verifies before every execution. By default
+// it is -1, which disables the verification, or the number of
+// placeholders in the prepared query with CountPlaceholdersOption.
+func (e *ExpectedPrepare) WithNumInput(n int) *ExpectedPrepare {
+	e.numInput = n
+	e.hasNumInput = true
+	return e
+}
+
+// WillBeExecuted expects the prepared statement to be executed exactly
+// the given number of times, by Query or Exec, which is verified by
+// ExpectationsWereMet.
+func (e *ExpectedPrepare) WillBeExecuted(times int) *ExpectedPrepare {
+	e.mustExecute = times
+	return e
+}
+
 // ExpectQuery allows to expect Query() or QueryRow() on this prepared statement.
 // This method is convenient in order to prevent duplicating sql query string matching.
 func (e *ExpectedPrepare) ExpectQuery() *ExpectedQuery {
 	eq := &ExpectedQuery{}
 	eq.expectSQL = e.expectSQL
+	eq.namedSQL = e.namedSQL
 	eq.converter = e.mock.converter
-	e.mock.expected = append(e.mock.expected, eq)
+	eq.mock = e.mock
+	eq.sqlMatcher.matcher = e.sqlMatcher.matcher
+	e.mock.add(eq)
 	return eq
 }
 
@@ -309,8 +399,11 @@ func (e *ExpectedPrepare) ExpectQuery() *ExpectedQuery {
 func (e *ExpectedPrepare) ExpectExec() *ExpectedExec {
 	eq := &ExpectedExec{}
 	eq.expectSQL = e.expectSQL
+	eq.namedSQL = e.namedSQL
 	eq.converter = e.mock.converter
-	e.mock.expected = append(e.mock.expected, eq)
+	eq.mock = e.mock
+	eq.sqlMatcher.matcher = e.sqlMatcher.matcher
+	e.mock.add(eq)
 	return eq
 }
 
@@ -318,6 +411,9 @@ func (e *ExpectedPrepare) ExpectExec() *ExpectedExec {
 func (e *ExpectedPrepare) String() string {
 	msg := "ExpectedPrepare => expecting Prepare statement which:"
 	msg += "\n  - matches sql: '" + e.expectSQL + "'"
+	if e.namedSQL != "" {
+		msg += "\n  - is compiled from named sql: '" + e.namedSQL + "'"
+	}
 
 	if e.err != nil {
 		msg += fmt.Sprintf("\n  - should return error: %s", e.err)
@@ -327,6 +423,14 @@ func (e *ExpectedPrepare) String() string {
 		msg += fmt.Sprintf("\n  - should return error on Close: %s", e.closeErr)
 	}
 
+	if e.hasNumInput {
+		msg += fmt.Sprintf("\n  - takes %d arguments", e.numInput)
+	}
+
+	if e.mustExecute > 0 {
+		msg += fmt.Sprintf("\n  - should be executed %d times, was executed %d times", e.mustExecute, e.executions)
+	}
+
 	return msg
 }
 
@@ -334,9 +438,66 @@ func (e *ExpectedPrepare) String() string {
 // adds a query matching logic
 type queryBasedExpectation struct {
 	commonExpectation
-	expectSQL string
-	converter driver.ValueConverter
-	args      []driver.Value
+	mock       *sqlmock
+	expectSQL  string
+	namedSQL   string // set, when expectSQL was compiled from named SQL
+	expandIn   bool   // set, when args were expanded by sqlx.In
+	inLists    string // lengths of the IN lists sqlx.In expanded
+	sqlMatcher sqlMatcher
+	converter  driver.ValueConverter
+	args       []driver.Value
+	outs       []outParam
+}
+
+// outParam is the value set to the Dest of a sql.Out argument,
+// which is found by its name or else by its 1-based ordinal.
+type outParam struct {
+	name    string
+	ordinal int
+	value   interface{}
+}
+
+func (p outParam) String() string {
+	if p.name != "" {
+		return fmt.Sprintf("should set output parameter '%s' to %s", p.name, formatValue(p.value))
+	}
+	return fmt.Sprintf("should set output parameter %d to %s", p.ordinal, formatValue(p.value))
+}
+
+// sqlMatcher holds the expected SQL of an expectation compiled
+// by the QueryMatcher of the mock, so that it is only parsed once.
+type sqlMatcher struct {
+	matcher  QueryMatcher // set by MatchWith, preferred to the one of the mock
+	compiled CompiledQuery
+	err      error
+}
+
+// compile prepares expectSQL, unless it was already compiled.
+func (m *sqlMatcher) compile(matcher QueryMatcher, expectSQL string) {
+	if m.matcher != nil {
+		matcher = m.matcher
+	}
+	if m.compiled == nil && m.err == nil {
+		m.compiled, m.err = compileQuery(matcher, expectSQL)
+	}
+}
+
+// match actual SQL against the compiled expectSQL.
+func (m *sqlMatcher) match(matcher QueryMatcher, expectSQL, actualSQL string) error {
+	m.compile(matcher, expectSQL)
+	if m.err != nil {
+		return m.err
+	}
+	return m.compiled.Match(actualSQL)
+}
+
+// matchArgs verifies the placeholders of actual SQL against the number of
+// arguments, if the compiled query supports it.
+func (m *sqlMatcher) matchArgs(actualSQL string, args int) error {
+	if q, ok := m.compiled.(argsQuery); ok {
+		return q.matchArgs(actualSQL, args)
+	}
+	return nil
 }
 
 // ExpectedPing is used to manage *sql.DB.Ping expectations.
@@ -5,7 +5,6 @@ package sqlmock
 import (
 	"database/sql/driver"
 	"fmt"
-	"reflect"
 )
 
 // WillReturnRows specifies the set of resulting rows that will be returned
@@ -28,7 +27,7 @@ func (e *queryBasedExpectation) argsMatches(args []namedValue) error {
 		if ok {
 			// @TODO: does it make sense to pass value instead of named value?
 			if !matcher.Match(v.Value) {
-				return fmt.Errorf("matcher %T could not match %d argument %T - %+v", matcher, k, args[k], args[k])
+				return fmt.Errorf("matcher %s could not match %d argument %T - %+v", describeArgument(matcher), k, args[k], args[k])
 			}
 			continue
 		}
@@ -44,13 +43,24 @@ func (e *queryBasedExpectation) argsMatches(args []namedValue) error {
 			return fmt.Errorf("argument %d: non-subset type %T returned from Value", k, darg)
 		}
 
-		if !reflect.DeepEqual(darg, v.Value) {
-			return fmt.Errorf("argument %d expected [%T - %+v] does not match actual [%T - %+v]", k, darg, darg, v.Value, v.Value)
+		if err := e.compareArg(k, darg, v.Value); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+// captureArgs records the actual arguments matched by capturing Arguments.
+func (e *queryBasedExpectation) captureArgs(args []namedValue) {
+	for k, v := range args {
+		if k < len(e.args) {
+			if c, ok := e.args[k].(capturer); ok {
+				c.capture(v.Value)
+			}
+		}
+	}
+}
+
 func (e *queryBasedExpectation) attemptArgMatch(args []namedValue) (err error) {
 	// catch panic
 	defer func() {
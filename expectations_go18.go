@@ -6,25 +6,24 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
-	"reflect"
 )
 
 // WillReturnRows specifies the set of resulting rows that will be returned
-// by the triggered query
+// by the triggered query. Every result set reports column metadata, the one
+// of NewRowsWithColumnDefinition or else the one inferred from its values,
+// see ColumnMetadataOption.
 func (e *ExpectedQuery) WillReturnRows(rows ...*Rows) *ExpectedQuery {
-	defs := 0
-	sets := make([]*Rows, len(rows))
-	for i, r := range rows {
-		sets[i] = r
-		if r.def != nil {
-			defs++
+	sets := make([]*Rows, 0, len(rows)+1)
+	for _, r := range rows {
+		if r.status != nil {
+			continue // replaced by the status of the expectation, if any
 		}
+		sets = append(sets, r)
 	}
-	if defs > 0 && defs == len(sets) {
-		e.rows = &rowSetsWithDefinition{&rowSets{sets: sets, ex: e}}
-	} else {
-		e.rows = &rowSets{sets: sets, ex: e}
+	if e.status != nil {
+		sets = append(sets, &Rows{status: e.status, nextErr: make(map[int]error), converter: driver.DefaultParameterConverter})
 	}
+	e.rows = &rowSetsWithDefinition{rowSets: &rowSets{sets: sets, ex: e}}
 	return e
 }
 
@@ -41,7 +40,7 @@ func (e *queryBasedExpectation) argsMatches(args []driver.NamedValue) error {
 		matcher, ok := e.args[k].(Argument)
 		if ok {
 			if !matcher.Match(v.Value) {
-				return fmt.Errorf("matcher %T could not match %d argument %T - %+v", matcher, k, args[k], args[k])
+				return fmt.Errorf("matcher %s could not match %d argument %T - %+v", describeArgument(matcher), k, args[k], args[k])
 			}
 			continue
 		}
@@ -56,19 +55,37 @@ func (e *queryBasedExpectation) argsMatches(args []driver.NamedValue) error {
 			return fmt.Errorf("argument %d: ordinal position: %d does not match expected: %d", k, k+1, v.Ordinal)
 		}
 
+		if isOut, err := e.outArgMatches(k, dval, v.Value); isOut {
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		// convert to driver converter
 		darg, err := e.converter.ConvertValue(dval)
 		if err != nil {
 			return fmt.Errorf("could not convert %d argument %T - %+v to driver value: %s", k, e.args[k], e.args[k], err)
 		}
 
-		if !reflect.DeepEqual(darg, v.Value) {
-			return fmt.Errorf("argument %d expected [%T - %+v] does not match actual [%T - %+v]", k, darg, darg, v.Value, v.Value)
+		if err := e.compareArg(k, darg, v.Value); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+// captureArgs records the actual arguments matched by capturing Arguments.
+func (e *queryBasedExpectation) captureArgs(args []driver.NamedValue) {
+	for k, v := range args {
+		if k < len(e.args) {
+			if c, ok := e.args[k].(capturer); ok {
+				c.capture(v.Value)
+			}
+		}
+	}
+}
+
 func (e *queryBasedExpectation) attemptArgMatch(args []driver.NamedValue) (err error) {
 	// catch panic
 	defer func() {
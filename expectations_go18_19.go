@@ -0,0 +1,15 @@
+// +build go1.8,!go1.9
+
+package sqlmock
+
+import "database/sql/driver"
+
+// outArgMatches is a no-op, since sql.Out is available from go1.9.
+func (e *queryBasedExpectation) outArgMatches(k int, expected, actual driver.Value) (bool, error) {
+	return false, nil
+}
+
+// setOutputs is a no-op, since sql.Out is available from go1.9.
+func (e *queryBasedExpectation) setOutputs(args []driver.NamedValue) error {
+	return nil
+}
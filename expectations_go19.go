@@ -0,0 +1,165 @@
+// +build go1.9
+
+package sqlmock
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+)
+
+// WillReturnOutput sets the Dest of the output parameter passed as
+// sql.Named(name, sql.Out{Dest: &dst}) to value, once the query is matched,
+// like a stored procedure does.
+func (e *ExpectedQuery) WillReturnOutput(name string, value interface{}) *ExpectedQuery {
+	e.outs = append(e.outs, outParam{name: name, value: value})
+	return e
+}
+
+// WillReturnOutputAt sets the Dest of the sql.Out argument at
+// the 1-based ordinal to value, once the query is matched.
+func (e *ExpectedQuery) WillReturnOutputAt(ordinal int, value interface{}) *ExpectedQuery {
+	e.outs = append(e.outs, outParam{ordinal: ordinal, value: value})
+	return e
+}
+
+// WillReturnStatus ends the result sets of the query with the status
+// of a CALL statement, like MySQL sends it after the result sets of
+// the queries in a stored procedure. It is read as a final result set
+// without any columns or rows.
+func (e *ExpectedQuery) WillReturnStatus(result driver.Result) *ExpectedQuery {
+	e.status = result
+	var sets []*Rows
+	if rs, ok := e.rows.(*rowSetsWithDefinition); ok {
+		sets = rs.sets
+	}
+	return e.WillReturnRows(sets...)
+}
+
+// WillReturnOutput sets the Dest of the output parameter passed as
+// sql.Named(name, sql.Out{Dest: &dst}) to value, once the exec is matched,
+// like a stored procedure does.
+func (e *ExpectedExec) WillReturnOutput(name string, value interface{}) *ExpectedExec {
+	e.outs = append(e.outs, outParam{name: name, value: value})
+	return e
+}
+
+// WillReturnOutputAt sets the Dest of the sql.Out argument at
+// the 1-based ordinal to value, once the exec is matched.
+func (e *ExpectedExec) WillReturnOutputAt(ordinal int, value interface{}) *ExpectedExec {
+	e.outs = append(e.outs, outParam{ordinal: ordinal, value: value})
+	return e
+}
+
+// outArgMatches compares an expected argument with an actual sql.Out one.
+// An expected sql.Out matches one with the same In flag and, if it is
+// InOut, the same input value. Any other expected value is compared with
+// the input value of an InOut argument. It returns false, if neither of
+// the arguments is a sql.Out.
+func (e *queryBasedExpectation) outArgMatches(k int, expected, actual driver.Value) (bool, error) {
+	out, ok := actual.(sql.Out)
+	if !ok {
+		if _, isOut := expected.(sql.Out); isOut {
+			return true, fmt.Errorf("argument %d expected to be an output parameter, but got [%T - %+v]", k, actual, actual)
+		}
+		return false, nil
+	}
+
+	if exp, isOut := expected.(sql.Out); isOut {
+		if exp.In != out.In {
+			return true, fmt.Errorf("argument %d expected output parameter with In: %t, but got In: %t", k, exp.In, out.In)
+		}
+		if !exp.In || exp.Dest == nil {
+			return true, nil
+		}
+		expected = derefOut(exp.Dest)
+	} else if !out.In {
+		return true, fmt.Errorf("argument %d expected [%T - %+v], but got an output parameter", k, expected, expected)
+	}
+
+	darg, err := e.converter.ConvertValue(expected)
+	if err != nil {
+		return true, fmt.Errorf("could not convert %d argument %T - %+v to driver value: %s", k, expected, expected, err)
+	}
+	in, err := e.converter.ConvertValue(derefOut(out.Dest))
+	if err != nil {
+		return true, fmt.Errorf("could not convert input of %d output parameter %T - %+v to driver value: %s", k, out.Dest, out.Dest, err)
+	}
+	return true, e.compareArg(k, darg, in)
+}
+
+// setOutputs writes the expected output parameters to the Dest of the
+// matching sql.Out arguments.
+func (e *queryBasedExpectation) setOutputs(args []driver.NamedValue) error {
+	for _, p := range e.outs {
+		var found bool
+		for _, arg := range args {
+			if p.name != "" && arg.Name != p.name || p.name == "" && arg.Ordinal != p.ordinal {
+				continue
+			}
+			out, ok := arg.Value.(sql.Out)
+			if !ok {
+				return fmt.Errorf("argument %s is not an output parameter, but [%T - %+v]", p.describe(), arg.Value, arg.Value)
+			}
+			if err := assignOut(out.Dest, p.value); err != nil {
+				return fmt.Errorf("could not set output parameter %s: %s", p.describe(), err)
+			}
+			found = true
+			break
+		}
+		if !found {
+			return fmt.Errorf("output parameter %s was not passed", p.describe())
+		}
+	}
+	return nil
+}
+
+func (p outParam) describe() string {
+	if p.name != "" {
+		return "'" + p.name + "'"
+	}
+	return fmt.Sprintf("%d", p.ordinal)
+}
+
+// derefOut returns the value the Dest pointer of a sql.Out points to.
+func derefOut(dest interface{}) interface{} {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return dest
+	}
+	return v.Elem().Interface()
+}
+
+// assignOut stores value to the dest pointer of a sql.Out, converting
+// numbers and text like a driver would.
+func assignOut(dest interface{}, value interface{}) error {
+	if scanner, ok := dest.(sql.Scanner); ok {
+		return scanner.Scan(value)
+	}
+	d := reflect.ValueOf(dest)
+	if d.Kind() != reflect.Ptr || d.IsNil() {
+		return fmt.Errorf("destination is not a pointer, but %T", dest)
+	}
+	d = d.Elem()
+	if value == nil {
+		d.Set(reflect.Zero(d.Type()))
+		return nil
+	}
+	v := reflect.ValueOf(value)
+	if v.Type().AssignableTo(d.Type()) {
+		d.Set(v)
+		return nil
+	}
+	_, _, _, fromNumber := toNumber(value)
+	_, _, _, toNumeric := toNumber(d.Interface())
+	if fromNumber && toNumeric {
+		d.Set(v.Convert(d.Type()))
+		return nil
+	}
+	if text, ok := driverText(value); ok && (d.Kind() == reflect.String || d.Type() == reflect.TypeOf([]byte(nil))) {
+		d.Set(reflect.ValueOf(text).Convert(d.Type()))
+		return nil
+	}
+	return fmt.Errorf("can not assign %T to %s", value, d.Type())
+}
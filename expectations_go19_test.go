@@ -4,6 +4,8 @@ package sqlmock
 
 import (
 	"context"
+	"database/sql"
+	"strings"
 	"testing"
 )
 
@@ -41,3 +43,100 @@ func TestCustomValueConverterExec(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestStoredProcedureOutputParameters(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	exec := mock.ExpectExec("CALL add_user").
+		WithArgs("john", sql.Out{}, sql.Named("counter", int64(7))).
+		WillReturnOutputAt(2, 42).
+		WillReturnOutput("counter", int64(8)).
+		WillReturnResult(NewResult(0, 1))
+
+	var id int
+	counter := int64(7)
+	if _, err := db.Exec("CALL add_user(?, ?, ?)", "john", sql.Out{Dest: &id}, sql.Named("counter", sql.Out{In: true, Dest: &counter})); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if id != 42 || counter != 8 {
+		t.Fatalf("expected output parameters 42 and 8, but got %d and %d", id, counter)
+	}
+
+	if !strings.Contains(exec.String(), "should set output parameter 2 to 42\n  - should set output parameter 'counter' to 8") {
+		t.Fatalf("expected output parameters in the representation, but got:\n%s", exec)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStoredProcedureInOutMismatch(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("CALL bump").WithArgs(int64(1)).WillReturnResult(NewResult(0, 1))
+	counter := int64(2)
+	_, err = db.Exec("CALL bump(?)", sql.Out{In: true, Dest: &counter})
+	if err == nil || !strings.Contains(err.Error(), "argument 0 expected [int64 - 1] does not match actual [int64 - 2]") {
+		t.Fatalf("expected the input value to be compared, but got: %v", err)
+	}
+	counter = 1
+	if _, err := db.Exec("CALL bump(?)", sql.Out{In: true, Dest: &counter}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	mock.ExpectExec("CALL bump").WithArgs(1).WillReturnOutput("missing", 1).WillReturnResult(NewResult(0, 1))
+	_, err = db.Exec("CALL bump(?)", 1)
+	if err == nil || err.Error() != "ExecQuery 'CALL bump(?)', output parameter 'missing' was not passed" {
+		t.Fatalf("expected a missing output parameter error, but got: %v", err)
+	}
+}
+
+func TestStoredProcedureResultSetsAndStatus(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	query := mock.ExpectQuery("CALL report").
+		WillReturnStatus(NewResult(0, 3)).
+		WillReturnRows(NewRows([]string{"id"}).AddRow(1), NewRows([]string{"name"}).AddRow("john"))
+
+	rows, err := db.Query("CALL report()")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer rows.Close()
+
+	var sets int
+	for {
+		for rows.Next() {
+		}
+		sets++
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	if sets != 3 {
+		t.Fatalf("expected 2 result sets and the status, but got %d sets", sets)
+	}
+	if cols, _ := rows.Columns(); len(cols) != 0 {
+		t.Fatalf("expected the status not to have columns, but got %v", cols)
+	}
+
+	if !strings.Contains(query.String(), "status - LastInsertId: 0, RowsAffected: 3") {
+		t.Fatalf("expected the status in the representation, but got:\n%s", query)
+	}
+}
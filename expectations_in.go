@@ -0,0 +1,114 @@
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// bindIn expects the query and arguments sqlx.In expands the SQL of the
+// expectation and args to. The SQL is written before the expansion, like
+// "SELECT * FROM users WHERE id IN (?)", and is matched regardless of the
+// bind type, by default with QueryMatcherAnyPlaceholder(QueryMatcherNormalized),
+// while every IN list must have as many values as the slice it was
+// expanded from. Arguments are flattened, so the values of a slice are
+// expected in order, each of them may be an Argument.
+func (e *queryBasedExpectation) bindIn(self expectation, args []interface{}) {
+	expanded, flat, err := sqlx.In(e.expectSQL, args...)
+	if err != nil {
+		panic(fmt.Errorf("could not expand sql '%s' with sqlx.In: %s", e.expectSQL, err))
+	}
+	e.args = make([]driver.Value, len(flat))
+	for i, v := range flat {
+		e.args[i] = v
+	}
+	e.expandIn = true
+	e.inLists = fmt.Sprint(inListLengths(expanded))
+
+	matcher := e.sqlMatcher.matcher
+	if matcher == nil {
+		matcher = QueryMatcherAnyPlaceholder(QueryMatcherNormalized)
+	}
+	e.mock.matchWith(self, &e.sqlMatcher, e.expectSQL, e.inListsMatcher(matcher))
+}
+
+// inListsMatcher wraps the matcher, so that it verifies the lengths
+// of the IN lists, if the arguments were expanded by sqlx.In.
+func (e *queryBasedExpectation) inListsMatcher(matcher QueryMatcher) QueryMatcher {
+	if !e.expandIn {
+		return matcher
+	}
+	return inListsMatcher{matcher: matcher, lists: e.inLists}
+}
+
+// inListsMatcher wraps a QueryMatcher, so that the IN lists of placeholders
+// in actual SQL must have the lengths sqlx.In expanded them to, which
+// matchers normalizing IN lists do not tell apart.
+type inListsMatcher struct {
+	matcher QueryMatcher
+	lists   string // lengths of the IN lists, formatted like [3 1]
+}
+
+func (m inListsMatcher) Match(expectedSQL, actualSQL string) error {
+	q, err := m.Compile(expectedSQL)
+	if err != nil {
+		return err
+	}
+	return q.Match(actualSQL)
+}
+
+func (m inListsMatcher) Compile(expectedSQL string) (CompiledQuery, error) {
+	q, err := compileQuery(m.matcher, expectedSQL)
+	if err != nil {
+		return nil, err
+	}
+	return &inListsQuery{query: q, lists: m.lists}, nil
+}
+
+type inListsQuery struct {
+	query CompiledQuery
+	lists string
+}
+
+func (q *inListsQuery) Match(actualSQL string) error {
+	if err := q.query.Match(actualSQL); err != nil {
+		return err
+	}
+	if lists := fmt.Sprint(inListLengths(actualSQL)); lists != q.lists {
+		return fmt.Errorf("sql has IN lists of %s values, but sqlx.In expanded them to %s values", lists, q.lists)
+	}
+	return nil
+}
+
+func (q *inListsQuery) matchArgs(actualSQL string, args int) error {
+	if aq, ok := q.query.(argsQuery); ok {
+		return aq.matchArgs(actualSQL, args)
+	}
+	return nil
+}
+
+// inListLengths returns the number of placeholders of every IN list
+// of placeholders in sql, in order.
+func inListLengths(sql string) []int {
+	tokens, err := tokenizeSQL(sql)
+	if err != nil {
+		return nil
+	}
+	lengths := []int{}
+	for i := 0; i+2 < len(tokens); i++ {
+		if !tokens[i].is(sqlWord, "in") || !tokens[i+1].is(sqlSymbol, "(") || tokens[i+2].kind != sqlPlaceholder {
+			continue
+		}
+		n, j := 1, i+3
+		for j+1 < len(tokens) && tokens[j].is(sqlSymbol, ",") && tokens[j+1].kind == sqlPlaceholder {
+			n++
+			j += 2
+		}
+		if j < len(tokens) && tokens[j].is(sqlSymbol, ")") {
+			lengths = append(lengths, n)
+			i = j
+		}
+	}
+	return lengths
+}
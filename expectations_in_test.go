@@ -0,0 +1,110 @@
+package sqlmock
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func TestExpectQueryIn(t *testing.T) {
+	t.Parallel()
+	db, mock, err := Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	query := "SELECT name FROM users WHERE id IN (?) AND status = ?"
+	for _, ids := range [][]int{{1}, {1, 2, 3}} {
+		mock.ExpectQueryIn(query, ids, "active").
+			WillReturnRows(NewRows([]string{"name"}).AddRow("john"))
+
+		sql, args, err := sqlx.In(query, ids, "active")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		rows, err := db.Query(sqlx.Rebind(sqlx.DOLLAR, sql), args...)
+		if err != nil {
+			t.Fatalf("unexpected error with %d ids: %s", len(ids), err)
+		}
+		rows.Close()
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExpectExecInArgsMismatch(t *testing.T) {
+	t.Parallel()
+	db, mock, err := Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	exec := mock.ExpectExecIn("DELETE FROM users WHERE id IN (?)", []int64{1, 2}).
+		WillReturnResult(NewResult(0, 2))
+
+	if _, err := db.Exec("DELETE FROM users WHERE id IN (?, ?)", int64(2), int64(1)); err == nil {
+		t.Fatal("expected an error, since arguments are not in order")
+	}
+
+	if _, err := db.Exec("DELETE FROM users WHERE id IN (?, ?)", int64(1), int64(2)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !strings.Contains(exec.String(), "is expanded by sqlx.In to IN lists of [2] values") {
+		t.Fatalf("expected the expansion in the representation, but got:\n%s", exec)
+	}
+}
+
+func TestExpectQueryInWithArgumentMatchers(t *testing.T) {
+	t.Parallel()
+	db, mock, err := Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQueryIn("SELECT name FROM users WHERE id IN (?)", []interface{}{AnyArg(), 2}).
+		WillReturnRows(NewRows([]string{"name"}))
+
+	rows, err := db.Query("SELECT name FROM users WHERE id IN (?, ?)", 7, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	rows.Close()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExpectQueryInListLengths(t *testing.T) {
+	t.Parallel()
+	db, mock, err := Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQueryIn("SELECT * FROM t WHERE a IN (?) AND b IN (?)", []int{1, 2, 3}, []int{4}).
+		WillReturnRows(NewRows([]string{"a"}).AddRow(1))
+
+	_, err = db.Query("SELECT * FROM t WHERE a IN (?) AND b IN (?, ?, ?)", 1, 2, 3, 4)
+	if err == nil || !strings.Contains(err.Error(), "sql has IN lists of [1 3] values, but sqlx.In expanded them to [3 1] values") {
+		t.Fatalf("expected the lengths of the IN lists to mismatch, but got: %v", err)
+	}
+
+	rows, err := db.Query("SELECT * FROM t WHERE a IN ($1, $2, $3) AND b IN ($4)", 1, 2, 3, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	rows.Close()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
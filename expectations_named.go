@@ -0,0 +1,122 @@
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// WithNamedStruct expects the query to be called like sqlx NamedQuery or
+// NamedStmt.Query are, with the given struct bound to named SQL.
+//
+// The SQL of this expectation is the named SQL, like
+// "SELECT * FROM users WHERE name = :name", rather than a regular
+// expression. It is compiled by sqlx to the question mark style
+// and the fields of arg, by their `db` tags, become the expected
+// arguments, which are matched like WithArgs does.
+//
+// Unless MatchWith was called before, the compiled SQL is matched
+// by QueryMatcherAnyPlaceholder(QueryMatcherEqual), so that it does
+// not matter which bind type sqlx picks for the driver.
+func (e *ExpectedQuery) WithNamedStruct(arg interface{}) *ExpectedQuery {
+	e.bindNamed(e, arg)
+	return e
+}
+
+// WithNamedMap expects the query to be called like sqlx NamedQuery or
+// NamedStmt.Query are, with the given map bound to named SQL.
+// See WithNamedStruct for how the SQL and arguments are matched.
+func (e *ExpectedQuery) WithNamedMap(arg map[string]interface{}) *ExpectedQuery {
+	e.bindNamed(e, arg)
+	return e
+}
+
+// WithNamedStruct expects the exec to be called like sqlx NamedExec or
+// NamedStmt.Exec are, with the given struct bound to named SQL.
+// See ExpectedQuery.WithNamedStruct for how the SQL and arguments are matched.
+func (e *ExpectedExec) WithNamedStruct(arg interface{}) *ExpectedExec {
+	e.bindNamed(e, arg)
+	return e
+}
+
+// WithNamedMap expects the exec to be called like sqlx NamedExec or
+// NamedStmt.Exec are, with the given map bound to named SQL.
+// See ExpectedQuery.WithNamedStruct for how the SQL and arguments are matched.
+func (e *ExpectedExec) WithNamedMap(arg map[string]interface{}) *ExpectedExec {
+	e.bindNamed(e, arg)
+	return e
+}
+
+// Named expects the statement to be prepared by sqlx PrepareNamed, so
+// the SQL of this expectation is named SQL, which is compiled by sqlx
+// to the question mark style. Query and Exec expectations on this
+// prepared statement bind their arguments to the named SQL with
+// WithNamedStruct or WithNamedMap.
+//
+//	prep := mock.ExpectPrepare("INSERT INTO users (name) VALUES (:name)").Named()
+//	prep.ExpectExec().WithNamedStruct(user).WillReturnResult(sqlmock.NewResult(1, 1))
+func (e *ExpectedPrepare) Named() *ExpectedPrepare {
+	if e.namedSQL == "" {
+		e.namedSQL = e.expectSQL
+	}
+	query, _, err := sqlx.Named(e.namedSQL, namedParams(e.namedSQL))
+	if err != nil {
+		panic(fmt.Errorf("could not compile named sql '%s': %s", e.namedSQL, err))
+	}
+	e.expectSQL = query
+	e.mock.matchWith(e, &e.sqlMatcher, e.expectSQL, namedQueryMatcher(e.sqlMatcher.matcher))
+	return e
+}
+
+// bindNamed compiles the named SQL of the expectation with arg,
+// the resulting positional SQL and arguments are expected instead.
+func (e *queryBasedExpectation) bindNamed(self expectation, arg interface{}) {
+	if e.namedSQL == "" {
+		e.namedSQL = e.expectSQL
+	}
+	query, args, err := sqlx.Named(e.namedSQL, arg)
+	if err != nil {
+		panic(fmt.Errorf("could not bind named sql '%s': %s", e.namedSQL, err))
+	}
+	e.args = make([]driver.Value, len(args))
+	for i, v := range args {
+		e.args[i] = v
+	}
+	e.expectSQL = query
+	e.mock.matchWith(self, &e.sqlMatcher, e.expectSQL, namedQueryMatcher(e.sqlMatcher.matcher))
+}
+
+// namedQueryMatcher is the QueryMatcher for SQL compiled from named SQL,
+// unless one was chosen with MatchWith.
+func namedQueryMatcher(matcher QueryMatcher) QueryMatcher {
+	if matcher != nil {
+		return matcher
+	}
+	return QueryMatcherAnyPlaceholder(QueryMatcherEqual)
+}
+
+// namedParams maps every name bound in sql to nil, scanning it the way
+// sqlx does, so that named SQL can be compiled without any arguments.
+func namedParams(sql string) map[string]interface{} {
+	params := make(map[string]interface{})
+	s := []rune(sql)
+	for i := 0; i < len(s)-1; i++ {
+		if s[i] != ':' {
+			continue
+		}
+		if s[i+1] == ':' {
+			i++ // an escaped colon
+			continue
+		}
+		j := i + 1
+		for j < len(s) && (isWordRune(s[j]) || s[j] == '.') {
+			j++
+		}
+		if j > i+1 {
+			params[string(s[i+1:j])] = nil
+		}
+		i = j - 1
+	}
+	return params
+}
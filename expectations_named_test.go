@@ -0,0 +1,109 @@
+package sqlmock
+
+import (
+	"strings"
+	"testing"
+)
+
+type namedUser struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+	Nick string
+}
+
+func TestNamedStructExpectations(t *testing.T) {
+	t.Parallel()
+	db, mock, err := Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	user := namedUser{ID: 1, Name: "john", Nick: "johnny"}
+	mock.ExpectExec("UPDATE users SET name = :name, nick = :nick WHERE id = :id").
+		WithNamedStruct(user).
+		WillReturnResult(NewResult(0, 1))
+	mock.ExpectQuery("SELECT name FROM users WHERE id = :id").
+		WithNamedMap(map[string]interface{}{"id": 1}).
+		WillReturnRows(NewRows([]string{"name"}).AddRow("john"))
+
+	if _, err := db.NamedExec("UPDATE users SET name = :name, nick = :nick WHERE id = :id", user); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	rows, err := db.NamedQuery("SELECT name FROM users WHERE id = :id", map[string]interface{}{"id": 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		t.Fatal("expected a row")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNamedStructExpectationArgsMismatch(t *testing.T) {
+	t.Parallel()
+	db, mock, err := Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	exec := mock.ExpectExec("DELETE FROM users WHERE id = :id").
+		WithNamedStruct(namedUser{ID: 1}).
+		WillReturnResult(NewResult(0, 1))
+
+	if _, err := db.NamedExec("DELETE FROM users WHERE id = :id", namedUser{ID: 2}); err == nil {
+		t.Fatal("expected an error, since arguments do not match")
+	}
+
+	if !strings.Contains(exec.String(), "is compiled from named sql: 'DELETE FROM users WHERE id = :id'") {
+		t.Fatalf("expected named sql in the representation, but got:\n%s", exec)
+	}
+}
+
+func TestPrepareNamedExpectations(t *testing.T) {
+	t.Parallel()
+	db, mock, err := Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	prep := mock.ExpectPrepare("INSERT INTO users (id, name, nick) VALUES (:id, :name, :nick)").Named()
+	prep.ExpectExec().WithNamedStruct(namedUser{ID: 1, Name: "john"}).WillReturnResult(NewResult(1, 1))
+	prep.ExpectExec().WithNamedMap(map[string]interface{}{"id": 2, "name": "jane", "nick": "janie"}).WillReturnResult(NewResult(2, 1))
+
+	stmt, err := db.PrepareNamed("INSERT INTO users (id, name, nick) VALUES (:id, :name, :nick)")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(namedUser{ID: 1, Name: "john"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := stmt.Exec(map[string]interface{}{"id": 2, "name": "jane", "nick": "janie"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNamedParams(t *testing.T) {
+	params := namedParams("SELECT id::text FROM users WHERE name = :name AND org = :user.org")
+	if len(params) != 2 {
+		t.Fatalf("expected 2 params, but got %v", params)
+	}
+	for _, name := range []string{"name", "user.org"} {
+		if _, ok := params[name]; !ok {
+			t.Errorf("expected param %q in %v", name, params)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sort"
+)
+
+// WillReturnResults arranges for a statement executed repeatedly, like an
+// INSERT for every item of a batch, to return the given results in sequence,
+// one for every call. The expectation is fulfilled once every result was
+// returned, unless Times expects another number of calls, in which case the
+// calls after the last result return it again.
+// NewResultSequence builds results with incrementing last insert ids.
+func (e *ExpectedExec) WillReturnResults(results ...driver.Result) *ExpectedExec {
+	e.results = results
+	return e
+}
+
+// WillReturnErrorAt allows to set an error for the call at the given zero
+// based position only, while the other calls return their results. The
+// expectation is expected to be called at least up to that call.
+func (e *ExpectedExec) WillReturnErrorAt(call int, err error) *ExpectedExec {
+	if call < 0 {
+		panic(fmt.Errorf("call %d must not be negative", call))
+	}
+	if e.callErrs == nil {
+		e.callErrs = make(map[int]error)
+	}
+	e.callErrs[call] = err
+	return e
+}
+
+// Times expects the statement to be executed n times, the expectation
+// is fulfilled by the last of them. By default it is executed once, or
+// as many times as results or errors are returned in sequence.
+func (e *ExpectedExec) Times(n int) *ExpectedExec {
+	if n < 1 {
+		panic(fmt.Errorf("expected number of calls %d must be positive", n))
+	}
+	e.times = n
+	return e
+}
+
+// expectedCalls returns the number of calls fulfilling the expectation.
+func (e *ExpectedExec) expectedCalls() int {
+	if e.times > 0 {
+		return e.times
+	}
+	n := 1
+	if len(e.results) > n {
+		n = len(e.results)
+	}
+	for call := range e.callErrs {
+		if call+1 > n {
+			n = call + 1
+		}
+	}
+	return n
+}
+
+// call counts a call of the expectation and returns the result or the
+// error of that call. The expectation is triggered by its last call.
+func (e *ExpectedExec) call() (driver.Result, error) {
+	n := e.calls
+	e.calls++
+	if e.calls >= e.expectedCalls() {
+		e.triggered = true
+	}
+
+	if err, ok := e.callErrs[n]; ok {
+		return nil, err
+	}
+	if e.err != nil {
+		return nil, e.err
+	}
+	if len(e.results) == 0 {
+		return e.result, nil
+	}
+	if n >= len(e.results) {
+		n = len(e.results) - 1
+	}
+	return e.results[n], nil
+}
+
+// sequenceString describes the calls and results in sequence,
+// it is empty for an expectation of a single call.
+func (e *ExpectedExec) sequenceString() string {
+	var msg string
+	if calls := e.expectedCalls(); calls > 1 {
+		msg += fmt.Sprintf("\n  - is expected to be called %d times, was called %d times", calls, e.calls)
+	}
+	if len(e.results) > 0 {
+		msg += "\n  - should return Results in sequence:"
+		for i, res := range e.results {
+			msg += fmt.Sprintf("\n      %d - %s", i, resultString(res))
+		}
+	}
+	calls := make([]int, 0, len(e.callErrs))
+	for call := range e.callErrs {
+		calls = append(calls, call)
+	}
+	sort.Ints(calls)
+	for _, call := range calls {
+		msg += fmt.Sprintf("\n  - should return error at call %d: %s", call, e.callErrs[call])
+	}
+	return msg
+}
@@ -0,0 +1,102 @@
+package sqlmock
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestExecResultSequence(t *testing.T) {
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO items").
+		WillReturnResults(NewResultSequence(10, 1, 1, 2)...)
+
+	for i, want := range []struct{ id, affected int64 }{{10, 1}, {11, 1}, {12, 2}} {
+		res, err := db.Exec("INSERT INTO items")
+		if err != nil {
+			t.Fatalf("call %d: error was not expected, but got: %s", i, err)
+		}
+		id, _ := res.LastInsertId()
+		affected, _ := res.RowsAffected()
+		if id != want.id || affected != want.affected {
+			t.Errorf("call %d: expected id %d and %d affected, but got %d and %d", i, want.id, want.affected, id, affected)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+	if _, err := db.Exec("INSERT INTO items"); err == nil {
+		t.Error("expected an error for a call after the sequence")
+	}
+}
+
+func TestExecResultSequenceErrorAt(t *testing.T) {
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	failure := fmt.Errorf("duplicate key")
+	mock.ExpectExec("INSERT INTO items").
+		WillReturnResult(NewResult(0, 1)).
+		WillReturnErrorAt(1, failure).
+		Times(4)
+
+	var errs []error
+	for i := 0; i < 4; i++ {
+		_, err := db.Exec("INSERT INTO items")
+		errs = append(errs, err)
+	}
+	if errs[0] != nil || errs[1] != failure || errs[2] != nil || errs[3] != nil {
+		t.Errorf("expected only the second call to fail, but got %v", errs)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestExecResultSequenceUnfulfilled(t *testing.T) {
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO items").WillReturnResult(NewResult(1, 1)).Times(3)
+	if _, err := db.Exec("INSERT INTO items"); err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+
+	err = mock.ExpectationsWereMet()
+	if err == nil {
+		t.Fatal("expected the expectation to be unfulfilled after a single call")
+	}
+	if !strings.Contains(err.Error(), "is expected to be called 3 times, was called 1 times") {
+		t.Errorf("expected the calls to be reported, but got: %s", err)
+	}
+}
+
+func TestExecResultSequenceString(t *testing.T) {
+	e := &ExpectedExec{}
+	e.expectSQL = "INSERT INTO items"
+	e.WillReturnResults(NewResultSequence(5, 1, 3)...).WillReturnErrorAt(2, fmt.Errorf("deadlock"))
+
+	expected := `ExpectedExec => expecting Exec or ExecContext which:
+  - matches sql: 'INSERT INTO items'
+  - is without arguments
+  - is expected to be called 3 times, was called 0 times
+  - should return Results in sequence:
+      0 - LastInsertId: 5, RowsAffected: 1
+      1 - LastInsertId: 6, RowsAffected: 3
+  - should return error at call 2: deadlock`
+	if s := e.String(); s != expected {
+		t.Errorf("expected:\n%s\nbut got:\n%s", expected, s)
+	}
+}
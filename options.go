@@ -37,6 +37,30 @@ func MonitorPingsOption(monitorPings bool) func(*sqlmock) error {
 	}
 }
 
+// RowsMustBeFullyReadOption determines whether every row of every query
+// must be read before the rows are closed, like
+// ExpectedQuery.RowsMustBeFullyRead expects for a single query.
+func RowsMustBeFullyReadOption(mustBeRead bool) func(*sqlmock) error {
+	return func(s *sqlmock) error {
+		s.rowsMustBeRead = mustBeRead
+		return nil
+	}
+}
+
+// CountPlaceholdersOption determines whether prepared statements report
+// the number of placeholders in their query as the number of arguments
+// they take, so that database/sql verifies the arguments of every
+// execution. It is off by default, since a question mark or an at sign
+// is not always a placeholder, like the ? operator of Postgres jsonb or
+// a MySQL variable like @@version. ExpectedPrepare.WithNumInput declares
+// the number of arguments of a single statement.
+func CountPlaceholdersOption(count bool) func(*sqlmock) error {
+	return func(s *sqlmock) error {
+		s.countPlaceholders = count
+		return nil
+	}
+}
+
 // >>>>> >>>>> >>>>> for mocker
 
 // The following design utilizes [Function Options Pattern].
@@ -94,6 +118,7 @@ type BasicOptions struct {
 type MockOptions struct {
 	ConfigSubFolder string // Setting up sub-paths (设定子路径) ❗️
 	ConfigFile      []string
+	SqlmockOptions  []func(*sqlmock) error // Options for the mock, like QueryMatcherOption.
 }
 
 // DBOptions holds database configuration options.
@@ -0,0 +1,149 @@
+package sqlmock
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// protocol keeps the violations of the driver protocol, see StrictProtocolOption.
+type protocol struct {
+	sync.Mutex
+	strict     bool
+	violations []string
+	tx         int            // transaction in progress, 0 if there is none
+	txs        int            // number of transactions begun
+	ended      map[int]string // how transactions ended, "committed" or "rolled back"
+}
+
+// StrictProtocolOption determines whether misuse of the rows returned by
+// the mock is reported, which real drivers often do not tolerate, even
+// though the mock would:
+//
+//   - rows.Next or rows.Columns called after rows.Close
+//   - rows.Next called again after it returned io.EOF
+//   - rows.Next called with fewer destinations than columns
+//   - rows read after the transaction they were queried in was
+//     committed or rolled back
+//
+// Every violation is returned as an error where the driver interface
+// allows it, and is reported by ExpectationsWereMet. Transactions are
+// followed as if the mock was used by a single connection at a time.
+func StrictProtocolOption(strict bool) func(*sqlmock) error {
+	return func(s *sqlmock) error {
+		s.protocol.strict = strict
+		return nil
+	}
+}
+
+// beginTransaction tracks a transaction which was begun.
+func (c *sqlmock) beginTransaction() {
+	c.track("transaction", "", nil)
+
+	c.protocol.Lock()
+	defer c.protocol.Unlock()
+	c.protocol.txs++
+	c.protocol.tx = c.protocol.txs
+}
+
+// finishTransaction ends the transaction in progress,
+// how is either "committed" or "rolled back".
+func (c *sqlmock) finishTransaction(how string) {
+	c.endTransaction()
+
+	c.protocol.Lock()
+	defer c.protocol.Unlock()
+	if c.protocol.tx == 0 {
+		return
+	}
+	if c.protocol.ended == nil {
+		c.protocol.ended = make(map[int]string)
+	}
+	c.protocol.ended[c.protocol.tx] = how
+	c.protocol.tx = 0
+}
+
+// transaction returns the transaction in progress, 0 if there is none.
+func (c *sqlmock) transaction() int {
+	c.protocol.Lock()
+	defer c.protocol.Unlock()
+	return c.protocol.tx
+}
+
+// transactionEnded tells how the transaction ended, if it did.
+func (c *sqlmock) transactionEnded(tx int) (string, bool) {
+	c.protocol.Lock()
+	defer c.protocol.Unlock()
+	how, ok := c.protocol.ended[tx]
+	return how, ok
+}
+
+// violate records a violation of the driver protocol, if it is strict,
+// and returns it as an error.
+func (c *sqlmock) violate(format string, args ...interface{}) error {
+	err := fmt.Errorf(format, args...)
+	c.protocol.Lock()
+	defer c.protocol.Unlock()
+	if c.protocol.strict {
+		c.protocol.violations = append(c.protocol.violations, err.Error())
+	}
+	return err
+}
+
+// protocolViolations returns an error listing the violations of the driver protocol.
+func (c *sqlmock) protocolViolations() error {
+	c.protocol.Lock()
+	defer c.protocol.Unlock()
+	if len(c.protocol.violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("the driver protocol was violated %d times:\n  - %s", len(c.protocol.violations), strings.Join(c.protocol.violations, "\n  - "))
+}
+
+// strict tells whether the rows check the driver protocol.
+func (rs *rowSets) strict() bool {
+	if rs.ex == nil || rs.ex.mock == nil {
+		return false
+	}
+	c := rs.ex.mock
+	c.protocol.Lock()
+	defer c.protocol.Unlock()
+	return c.protocol.strict
+}
+
+// checkNext verifies a call to rows.Next, it returns an error for
+// a violation which is not tolerated by the mock either.
+func (rs *rowSets) checkNext(dest int) error {
+	if cols := len(rs.sets[rs.pos].cols); dest < cols {
+		return rs.violate("rows.Next was called with %d destinations, but there are %d columns", dest, cols)
+	}
+	if !rs.strict() {
+		return nil
+	}
+	if rs.closed {
+		return rs.violate("rows.Next was called after rows.Close")
+	}
+	if rs.eof {
+		return rs.violate("rows.Next was called again after it returned io.EOF")
+	}
+	if rs.ex.tx != 0 {
+		if how, ok := rs.ex.mock.transactionEnded(rs.ex.tx); ok {
+			return rs.violate("rows of the transaction were read after it was %s", how)
+		}
+	}
+	return nil
+}
+
+// checkColumns verifies a call to rows.Columns.
+func (rs *rowSets) checkColumns() {
+	if rs.closed && rs.strict() {
+		rs.violate("rows.Columns was called after rows.Close")
+	}
+}
+
+func (rs *rowSets) violate(format string, args ...interface{}) error {
+	if rs.ex == nil || rs.ex.mock == nil {
+		return fmt.Errorf(format, args...)
+	}
+	return rs.ex.mock.violate(format, args...)
+}
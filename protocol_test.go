@@ -0,0 +1,150 @@
+package sqlmock
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+)
+
+// strictConn returns the mock as the driver connection database/sql uses.
+func strictConn(t *testing.T) (*sqlmock, func()) {
+	db, mock, err := New(StrictProtocolOption(true))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	return mock.(*sqlmock), func() { db.Close() }
+}
+
+func TestStrictProtocolNextAfterClose(t *testing.T) {
+	c, closeDB := strictConn(t)
+	defer closeDB()
+
+	c.ExpectQuery("SELECT").WillReturnRows(NewRows([]string{"id"}).AddRow(1))
+	rows, err := c.QueryContext(context.Background(), "SELECT", nil)
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	rows.Close()
+
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err == nil || err.Error() != "rows.Next was called after rows.Close" {
+		t.Errorf("expected a violation for Next after Close, but got: %v", err)
+	}
+	rows.Columns()
+
+	err = c.ExpectationsWereMet()
+	if err == nil {
+		t.Fatal("expected the violations to be reported")
+	}
+	expected := `the driver protocol was violated 2 times:
+  - rows.Next was called after rows.Close
+  - rows.Columns was called after rows.Close`
+	if err.Error() != expected {
+		t.Errorf("expected:\n%s\nbut got:\n%s", expected, err)
+	}
+}
+
+func TestStrictProtocolNextAfterEOF(t *testing.T) {
+	c, closeDB := strictConn(t)
+	defer closeDB()
+
+	c.ExpectQuery("SELECT").WillReturnRows(NewRows([]string{"id"}).AddRow(1))
+	rows, err := c.QueryContext(context.Background(), "SELECT", nil)
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	defer rows.Close()
+
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	if err := rows.Next(dest); err != io.EOF {
+		t.Fatalf("expected io.EOF, but got: %v", err)
+	}
+	if err := rows.Next(dest); err == nil || !strings.Contains(err.Error(), "again after it returned io.EOF") {
+		t.Errorf("expected a violation for Next after io.EOF, but got: %v", err)
+	}
+}
+
+func TestStrictProtocolTooFewDestinations(t *testing.T) {
+	for _, strict := range []bool{true, false} {
+		db, mock, err := New(StrictProtocolOption(strict))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		c := mock.(*sqlmock)
+		c.ExpectQuery("SELECT").WillReturnRows(NewRows([]string{"id", "name"}).AddRow(1, "john"))
+		rows, err := c.QueryContext(context.Background(), "SELECT", nil)
+		if err != nil {
+			t.Fatalf("error was not expected, but got: %s", err)
+		}
+		err = rows.Next(make([]driver.Value, 1))
+		if err == nil || err.Error() != "rows.Next was called with 1 destinations, but there are 2 columns" {
+			t.Errorf("strict %t: expected an error for too few destinations, but got: %v", strict, err)
+		}
+		if err := c.ExpectationsWereMet(); (err != nil) != strict {
+			t.Errorf("strict %t: unexpected violations reported: %v", strict, err)
+		}
+		db.Close()
+	}
+}
+
+func TestStrictProtocolRowsOfCommittedTransaction(t *testing.T) {
+	c, closeDB := strictConn(t)
+	defer closeDB()
+
+	c.ExpectBegin()
+	c.ExpectQuery("SELECT").WillReturnRows(NewRows([]string{"id"}).AddRow(1))
+	c.ExpectCommit()
+
+	if _, err := c.Begin(); err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	rows, err := c.QueryContext(context.Background(), "SELECT", nil)
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	if err := c.Commit(); err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+
+	err = rows.Next(make([]driver.Value, 1))
+	if err == nil || err.Error() != "rows of the transaction were read after it was committed" {
+		t.Errorf("expected a violation for rows of a committed transaction, but got: %v", err)
+	}
+	rows.Close()
+}
+
+func TestStrictProtocolDatabaseSQL(t *testing.T) {
+	db, mock, err := New(StrictProtocolOption(true))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT").WillReturnRows(NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	rows, err := tx.Query("SELECT")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	for rows.Next() {
+	}
+	rows.Close()
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("database/sql was not expected to violate the driver protocol: %s", err)
+	}
+}
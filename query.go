@@ -39,30 +39,112 @@ func (f QueryMatcherFunc) Match(expectedSQL, actualSQL string) error {
 	return f(expectedSQL, actualSQL)
 }
 
+// CompiledQuery is an expected SQL query string prepared once
+// by a QueryMatcherCompiler, which can then be matched against
+// any number of actual SQL query strings.
+type CompiledQuery interface {
+
+	// Match the prepared expected SQL query to actual SQL.
+	Match(actualSQL string) error
+}
+
+// QueryMatcherCompiler is an optional interface a QueryMatcher
+// may implement. sqlmock compiles the SQL query string of every
+// expectation once and reuses it for all the calls it is matched
+// against, instead of parsing it again on each of them.
+type QueryMatcherCompiler interface {
+	QueryMatcher
+
+	// Compile expected SQL query string for later matching.
+	Compile(expectedSQL string) (CompiledQuery, error)
+}
+
+// exactQuery is implemented by compiled queries, which only
+// ever match a single stripped SQL query string. Such
+// expectations are indexed by the mock for direct lookup.
+type exactQuery interface {
+	exactSQL() string
+}
+
+// compileQuery prepares expectedSQL for matching with the given
+// QueryMatcher. Matchers which are not a QueryMatcherCompiler
+// are simply called with expectedSQL on every match.
+func compileQuery(matcher QueryMatcher, expectedSQL string) (CompiledQuery, error) {
+	if compiler, ok := matcher.(QueryMatcherCompiler); ok {
+		return compiler.Compile(expectedSQL)
+	}
+	return &boundQuery{matcher: matcher, expectedSQL: expectedSQL}, nil
+}
+
+type boundQuery struct {
+	matcher     QueryMatcher
+	expectedSQL string
+}
+
+func (q *boundQuery) Match(actualSQL string) error {
+	return q.matcher.Match(q.expectedSQL, actualSQL)
+}
+
 // QueryMatcherRegexp is the default SQL query matcher
 // used by sqlmock. It parses expectedSQL to a regular
 // expression and attempts to match actualSQL.
-var QueryMatcherRegexp QueryMatcher = QueryMatcherFunc(func(expectedSQL, actualSQL string) error {
-	expect := stripQuery(expectedSQL)
-	actual := stripQuery(actualSQL)
-	re, err := regexp.Compile(expect)
+var QueryMatcherRegexp QueryMatcher = regexpQueryMatcher{}
+
+type regexpQueryMatcher struct{}
+
+func (m regexpQueryMatcher) Match(expectedSQL, actualSQL string) error {
+	q, err := m.Compile(expectedSQL)
 	if err != nil {
 		return err
 	}
+	return q.Match(actualSQL)
+}
+
+func (regexpQueryMatcher) Compile(expectedSQL string) (CompiledQuery, error) {
+	re, err := regexp.Compile(stripQuery(expectedSQL))
+	if err != nil {
+		return nil, err
+	}
+	return (*regexpQuery)(re), nil
+}
+
+type regexpQuery regexp.Regexp
+
+func (q *regexpQuery) Match(actualSQL string) error {
+	re := (*regexp.Regexp)(q)
+	actual := stripQuery(actualSQL)
 	if !re.MatchString(actual) {
 		return fmt.Errorf(`could not match actual sql: "%s" with expected regexp "%s"`, actual, re.String())
 	}
 	return nil
-})
+}
 
 // QueryMatcherEqual is the SQL query matcher
 // which simply tries a case sensitive match of
 // expected and actual SQL strings without whitespace.
-var QueryMatcherEqual QueryMatcher = QueryMatcherFunc(func(expectedSQL, actualSQL string) error {
-	expect := stripQuery(expectedSQL)
+var QueryMatcherEqual QueryMatcher = equalQueryMatcher{}
+
+type equalQueryMatcher struct{}
+
+func (m equalQueryMatcher) Match(expectedSQL, actualSQL string) error {
+	q, _ := m.Compile(expectedSQL)
+	return q.Match(actualSQL)
+}
+
+func (equalQueryMatcher) Compile(expectedSQL string) (CompiledQuery, error) {
+	return equalQuery(stripQuery(expectedSQL)), nil
+}
+
+type equalQuery string
+
+func (q equalQuery) Match(actualSQL string) error {
 	actual := stripQuery(actualSQL)
-	if actual != expect {
-		return fmt.Errorf(`actual sql: "%s" does not equal to expected "%s"`, actual, expect)
+	if actual != string(q) {
+		return fmt.Errorf(`actual sql: "%s" does not equal to expected "%s"`, actual, q)
 	}
 	return nil
-})
+}
+
+func (q equalQuery) exactSQL() string {
+	return string(q)
+}
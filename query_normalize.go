@@ -0,0 +1,322 @@
+package sqlmock
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// QueryMatcherNormalized is the SQL query matcher which tokenizes
+// both expected and actual SQL and compares the normalized token
+// streams, so that cosmetic differences do not break expectations:
+//
+//   - keywords and identifiers are compared case insensitively
+//   - identifiers quoted with backticks or double quotes are
+//     equal to the same identifier without quotes, but double
+//     quoted ones are case sensitive, like in Postgres, so "User"
+//     is not equal to user, while "user" is
+//   - comments, including MySQL # comments, and trailing semicolons
+//     are ignored
+//   - redundant parentheses are ignored
+//   - IN lists of placeholders match regardless of their length
+//
+// String literals, numbers and placeholders must still be the same.
+var QueryMatcherNormalized QueryMatcher = normalizedQueryMatcher{}
+
+type normalizedQueryMatcher struct{}
+
+func (m normalizedQueryMatcher) Match(expectedSQL, actualSQL string) error {
+	q, err := m.Compile(expectedSQL)
+	if err != nil {
+		return err
+	}
+	return q.Match(actualSQL)
+}
+
+func (normalizedQueryMatcher) Compile(expectedSQL string) (CompiledQuery, error) {
+	tokens, err := tokenizeSQL(expectedSQL)
+	if err != nil {
+		return nil, fmt.Errorf("could not tokenize expected sql: %s", err)
+	}
+	return normalizedQuery(normalizeSQL(tokens)), nil
+}
+
+type normalizedQuery []sqlToken
+
+func (q normalizedQuery) Match(actualSQL string) error {
+	tokens, err := tokenizeSQL(actualSQL)
+	if err != nil {
+		return fmt.Errorf("could not tokenize actual sql: %s", err)
+	}
+	actual := normalizeSQL(tokens)
+	for i := range q {
+		if i >= len(actual) || !q[i].is(actual[i].kind, actual[i].value) {
+			return fmt.Errorf(`actual sql: "%s" does not match expected "%s" at token %d`, renderSQL(actual), renderSQL(q), i)
+		}
+	}
+	if len(actual) > len(q) {
+		return fmt.Errorf(`actual sql: "%s" does not match expected "%s" at token %d`, renderSQL(actual), renderSQL(q), len(q))
+	}
+	return nil
+}
+
+type sqlTokenKind uint8
+
+const (
+	sqlWord        sqlTokenKind = iota // keyword or identifier, quoted or not
+	sqlString                          // string literal
+	sqlNumber                          // numeric literal
+	sqlPlaceholder                     // bind variable like ?, $1 or :name
+	sqlSymbol                          // operator or punctuation
+)
+
+type sqlToken struct {
+	kind       sqlTokenKind
+	value      string
+	start, end int // rune offsets in the tokenized SQL
+}
+
+func (t sqlToken) is(kind sqlTokenKind, value string) bool {
+	return t.kind == kind && t.value == value
+}
+
+// tokenizeSQL splits SQL into tokens, dropping whitespace and comments.
+// Words are lower cased and identifiers are unquoted, those in double
+// quotes keep their case. A # starts a comment, unless it is one of the
+// Postgres operators #>, #>> or #-.
+func tokenizeSQL(sql string) ([]sqlToken, error) {
+	var tokens []sqlToken
+	s := []rune(sql)
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '-' && i+1 < len(s) && s[i+1] == '-',
+			c == '#' && !(i+1 < len(s) && (s[i+1] == '>' || s[i+1] == '-')):
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(s) && s[i+1] == '*':
+			j := i + 2
+			for j+1 < len(s) && !(s[j] == '*' && s[j+1] == '/') {
+				j++
+			}
+			if j+1 >= len(s) {
+				return nil, fmt.Errorf("unterminated comment at position %d", i)
+			}
+			i = j + 2
+		case c == '\'':
+			j, err := closeQuote(s, i, '\'')
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, sqlToken{sqlString, string(s[i : j+1]), i, j + 1})
+			i = j + 1
+		case c == '`' || c == '"':
+			j, err := closeQuote(s, i, c)
+			if err != nil {
+				return nil, err
+			}
+			name := strings.ReplaceAll(string(s[i+1:j]), string([]rune{c, c}), string(c))
+			if c == '`' {
+				name = strings.ToLower(name)
+			}
+			tokens = append(tokens, sqlToken{sqlWord, name, i, j + 1})
+			i = j + 1
+		case unicode.IsDigit(c) || c == '.' && i+1 < len(s) && unicode.IsDigit(s[i+1]):
+			j := i + 1
+			for j < len(s) && (unicode.IsDigit(s[j]) || s[j] == '.' || isExponent(s, j)) {
+				if isExponent(s, j) {
+					j++ // the sign of the exponent
+				}
+				j++
+			}
+			tokens = append(tokens, sqlToken{sqlNumber, string(s[i:j]), i, j})
+			i = j
+		case c == '?':
+			tokens = append(tokens, sqlToken{sqlPlaceholder, "?", i, i + 1})
+			i++
+		case (c == '$' || c == ':' || c == '@') && i+1 < len(s) && isWordRune(s[i+1]) &&
+			!(c == ':' && i > 0 && s[i-1] == ':') && !(c == '@' && i > 0 && s[i-1] == '@'):
+			j := i + 1
+			for j < len(s) && isWordRune(s[j]) {
+				j++
+			}
+			tokens = append(tokens, sqlToken{sqlPlaceholder, string(s[i:j]), i, j})
+			i = j
+		case isWordRune(c):
+			j := i + 1
+			for j < len(s) && (isWordRune(s[j]) || s[j] == '$') {
+				j++
+			}
+			tokens = append(tokens, sqlToken{sqlWord, strings.ToLower(string(s[i:j])), i, j})
+			i = j
+		default:
+			j := i + 1
+			for _, op := range sqlOperators {
+				if strings.HasPrefix(string(s[i:]), op) {
+					j = i + len([]rune(op))
+					break
+				}
+			}
+			tokens = append(tokens, sqlToken{sqlSymbol, string(s[i:j]), i, j})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+// multi character operators, longest first
+var sqlOperators = []string{"->>", "#>>", "<=>", "<>", "<=", ">=", "!=", "||", "::", ":=", "->", "#>", "#-", "<<", ">>"}
+
+// isExponent tells whether s[i] starts the exponent of a number, like e-3
+func isExponent(s []rune, i int) bool {
+	if s[i] != 'e' && s[i] != 'E' || i+1 >= len(s) {
+		return false
+	}
+	if s[i+1] == '-' || s[i+1] == '+' {
+		return i+2 < len(s) && unicode.IsDigit(s[i+2])
+	}
+	return unicode.IsDigit(s[i+1])
+}
+
+func isWordRune(c rune) bool {
+	return c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+// closeQuote returns the position of the quote closing the one at start,
+// quotes are escaped by doubling them and, in string literals, by a backslash.
+func closeQuote(s []rune, start int, quote rune) (int, error) {
+	for i := start + 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quote == '\'':
+			i++
+		case s[i] == quote && i+1 < len(s) && s[i+1] == quote:
+			i++
+		case s[i] == quote:
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated quote %c at position %d", quote, start)
+}
+
+// words after which a parenthesized group is an expression
+// rather than a list of arguments or columns
+var sqlExpressionWords = map[string]bool{
+	"select": true, "where": true, "and": true, "or": true, "not": true, "on": true,
+	"having": true, "when": true, "then": true, "else": true, "return": true,
+	"returning": true, "by": true, "from": true, "join": true,
+}
+
+// words ending a condition of a clause
+var sqlClauseWords = map[string]bool{
+	"group": true, "order": true, "limit": true, "offset": true, "having": true,
+	"union": true, "intersect": true, "except": true, "for": true, "returning": true,
+	"window": true, "fetch": true,
+}
+
+// normalizeSQL removes the tokens which make no difference to the query.
+func normalizeSQL(tokens []sqlToken) []sqlToken {
+	for len(tokens) > 0 && tokens[len(tokens)-1].is(sqlSymbol, ";") {
+		tokens = tokens[:len(tokens)-1]
+	}
+	tokens = collapseInLists(tokens)
+	for {
+		reduced := dropRedundantParens(tokens)
+		if len(reduced) == len(tokens) {
+			return tokens
+		}
+		tokens = reduced
+	}
+}
+
+// collapseInLists reduces IN lists of placeholders to their first one.
+func collapseInLists(tokens []sqlToken) []sqlToken {
+	res := make([]sqlToken, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		res = append(res, tokens[i])
+		if !tokens[i].is(sqlWord, "in") || i+2 >= len(tokens) || !tokens[i+1].is(sqlSymbol, "(") || tokens[i+2].kind != sqlPlaceholder {
+			continue
+		}
+		j := i + 3
+		for j+1 < len(tokens) && tokens[j].is(sqlSymbol, ",") && tokens[j+1].kind == sqlPlaceholder {
+			j += 2
+		}
+		if j < len(tokens) && tokens[j].is(sqlSymbol, ")") {
+			res = append(res, tokens[i+1], tokens[i+2], tokens[j])
+			i = j
+		}
+	}
+	return res
+}
+
+// dropRedundantParens removes one level of parentheses which do not change
+// the meaning of the query: around a whole statement or condition, around a
+// single value in an expression, or doubled around the same content.
+func dropRedundantParens(tokens []sqlToken) []sqlToken {
+	pairs := make(map[int]int)
+	var stack []int
+	for i, t := range tokens {
+		switch {
+		case t.is(sqlSymbol, "("):
+			stack = append(stack, i)
+		case t.is(sqlSymbol, ")"):
+			if len(stack) == 0 {
+				return tokens // unbalanced, leave it as is
+			}
+			pairs[stack[len(stack)-1]] = i
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	// pairs are visited in order of their position, so that
+	// the same pair is dropped first every time
+	drop := make(map[int]bool)
+	for open := range tokens {
+		end, ok := pairs[open]
+		if !ok {
+			continue
+		}
+		var redundant bool
+		switch {
+		case open == 0 && end == len(tokens)-1:
+			redundant = true
+		case tokens[open+1].is(sqlSymbol, "(") && pairs[open+1] == end-1:
+			// drop the inner pair, so that function calls keep theirs
+			open, end, redundant = open+1, end-1, true
+		case open > 0 && tokens[open-1].kind == sqlWord && !sqlExpressionWords[tokens[open-1].value],
+			open > 0 && tokens[open-1].is(sqlSymbol, ")"):
+			// arguments of a function call, IN list or column list
+		case end == open+2:
+			redundant = true
+		case open > 0 && (tokens[open-1].is(sqlWord, "where") || tokens[open-1].is(sqlWord, "on") || tokens[open-1].is(sqlWord, "having")):
+			redundant = end == len(tokens)-1 || tokens[end+1].is(sqlSymbol, ")") ||
+				tokens[end+1].kind == sqlWord && sqlClauseWords[tokens[end+1].value]
+		}
+		if redundant && !drop[open] {
+			drop[open], drop[end] = true, true
+			break // positions of other pairs may change, one at a time
+		}
+	}
+
+	res := make([]sqlToken, 0, len(tokens))
+	for i, t := range tokens {
+		if !drop[i] {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// renderSQL prints normalized tokens for error messages.
+func renderSQL(tokens []sqlToken) string {
+	var sb strings.Builder
+	for i, t := range tokens {
+		if i > 0 && !t.is(sqlSymbol, ",") && !t.is(sqlSymbol, ")") && !tokens[i-1].is(sqlSymbol, "(") {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(t.value)
+	}
+	return sb.String()
+}
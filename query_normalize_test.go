@@ -0,0 +1,92 @@
+package sqlmock
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestQueryMatcherNormalized(t *testing.T) {
+	type testCase struct {
+		expected string
+		actual   string
+		err      error
+	}
+
+	cases := []testCase{
+		{"SELECT name FROM users WHERE id = ?", "select name\n  from users where id = ?", nil},
+		{"SELECT `name` FROM `users`", `SELECT "name" FROM users`, nil},
+		{"SELECT name FROM users;", "SELECT name FROM users -- all of them\n", nil},
+		{"SELECT name /* the name */ FROM users # mysql comment", "SELECT name FROM users;;", nil},
+		{"SELECT name FROM users WHERE (id = ? AND status = ?) ORDER BY name", "SELECT name FROM users WHERE id = ? AND status = ? ORDER BY name", nil},
+		{"SELECT name FROM users WHERE id = (?)", "SELECT name FROM users WHERE id = ?", nil},
+		{"SELECT COUNT((id)) FROM users", "SELECT count(id) FROM users", nil},
+		{"(SELECT name FROM users)", "SELECT name FROM users", nil},
+		{"SELECT name FROM users WHERE id IN (?)", "SELECT name FROM users WHERE id IN (?, ?, ?)", nil},
+		{"SELECT name FROM users WHERE id IN ($1, $2)", "SELECT name FROM users WHERE id IN ($1)", nil},
+		{"SELECT name FROM users WHERE name = 'John' AND rate > 1.5e-3", "select name from users where name = 'John' and rate > 1.5e-3", nil},
+		{"SELECT name FROM users WHERE name = 'John'", "SELECT name FROM users WHERE name = 'john'", fmt.Errorf(`actual sql: "select name from users where name = 'john'" does not match expected "select name from users where name = 'John'" at token 7`)},
+		{"SELECT name FROM users WHERE (a = 1) OR (b = 2)", "SELECT name FROM users WHERE a = 1 OR b = 2", fmt.Errorf(`actual sql: "select name from users where a = 1 or b = 2" does not match expected "select name from users where (a = 1) or (b = 2)" at token 5`)},
+		{"SELECT name FROM users WHERE id IN (1, 2)", "SELECT name FROM users WHERE id IN (1)", fmt.Errorf(`actual sql: "select name from users where id in (1)" does not match expected "select name from users where id in (1, 2)" at token 9`)},
+		{"SELECT name FROM users", "SELECT name FROM users WHERE id = ?", fmt.Errorf(`actual sql: "select name from users where id = ?" does not match expected "select name from users" at token 4`)},
+		{"SELECT name FROM users WHERE id = ?", "SELECT name FROM users WHERE id = $1", fmt.Errorf(`actual sql: "select name from users where id = $1" does not match expected "select name from users where id = ?" at token 7`)},
+		{"SELECT 'name FROM users", "SELECT name FROM users", fmt.Errorf("could not tokenize expected sql: unterminated quote ' at position 7")},
+		{"SELECT name FROM users #comment", "SELECT name FROM users", nil},
+		{"SELECT data #> '{a}' FROM docs", "SELECT data #> '{a}' FROM docs -- path", nil},
+		{"SELECT data #> '{a}' FROM docs", "SELECT data FROM docs", fmt.Errorf(`actual sql: "select data from docs" does not match expected "select data #> '{a}' from docs" at token 2`)},
+		{`SELECT name FROM "user"`, "SELECT name FROM USER", nil},
+		{`SELECT name FROM "User"`, "SELECT name FROM user", fmt.Errorf(`actual sql: "select name from user" does not match expected "select name from User" at token 3`)},
+	}
+
+	for i, c := range cases {
+		err := QueryMatcherNormalized.Match(c.expected, c.actual)
+		if err == nil && c.err != nil {
+			t.Errorf(`got no error, but expected "%v" at %d case`, c.err, i)
+			continue
+		}
+		if err != nil && c.err == nil {
+			t.Errorf(`got unexpected error "%v" at %d case`, err, i)
+			continue
+		}
+		if err == nil {
+			continue
+		}
+		if err.Error() != c.err.Error() {
+			t.Errorf(`expected error "%v", but got "%v" at %d case`, c.err, err, i)
+		}
+	}
+}
+
+func TestNormalizeSQLDeterministic(t *testing.T) {
+	tokens, err := tokenizeSQL("SELECT name FROM users WHERE ((a = (1)) AND ((b)) = (2))")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	first := renderSQL(normalizeSQL(tokens))
+	for i := 0; i < 50; i++ {
+		if normalized := renderSQL(normalizeSQL(tokens)); normalized != first {
+			t.Fatalf("expected the same normalized sql %q every time, but got %q", first, normalized)
+		}
+	}
+}
+
+func TestQueryMatcherNormalizedOption(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New(QueryMatcherOption(QueryMatcherNormalized))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE `users` SET `name` = ? WHERE `id` = ?;").
+		WithArgs("john", 1).
+		WillReturnResult(NewResult(0, 1))
+
+	_, err = db.Exec(`update "users" set "name" = ? where ("id" = ?)`, "john", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
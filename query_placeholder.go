@@ -0,0 +1,145 @@
+package sqlmock
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// QueryMatcherAnyPlaceholder wraps a QueryMatcher, so that bind variables
+// in the question mark (?), dollar ($1) and named (:name or @name) styles
+// are equivalent. That way an expectation is written once, no matter how
+// sqlx.Rebind formats the query for the driver in use.
+//
+// Actual SQL is rewritten to the question mark style before it is matched.
+// So is expected SQL, unless the wrapped matcher is QueryMatcherRegexp,
+// since placeholders can not be told apart from a regular expression.
+// Such expectations must be written in the question mark style, escaped
+// like regexp.QuoteMeta does, which is how LoadMockConfig loads fixtures.
+//
+// In addition, the number of placeholders in actual SQL must be equal to
+// the number of arguments the query or exec is called with.
+//
+//	db, mock, err := sqlmock.Newx(sqlmock.QueryMatcherOption(
+//		sqlmock.QueryMatcherAnyPlaceholder(sqlmock.QueryMatcherEqual),
+//	))
+func QueryMatcherAnyPlaceholder(matcher QueryMatcher) QueryMatcher {
+	return anyPlaceholderMatcher{matcher: matcher}
+}
+
+type anyPlaceholderMatcher struct {
+	matcher QueryMatcher
+}
+
+func (m anyPlaceholderMatcher) Match(expectedSQL, actualSQL string) error {
+	q, err := m.Compile(expectedSQL)
+	if err != nil {
+		return err
+	}
+	return q.Match(actualSQL)
+}
+
+func (m anyPlaceholderMatcher) Compile(expectedSQL string) (CompiledQuery, error) {
+	if _, isRegexp := m.matcher.(regexpQueryMatcher); !isRegexp {
+		if rebound, _, err := questionPlaceholders(expectedSQL); err == nil {
+			expectedSQL = rebound
+		}
+	}
+	q, err := compileQuery(m.matcher, expectedSQL)
+	if err != nil {
+		return nil, err
+	}
+	return &anyPlaceholderQuery{query: q}, nil
+}
+
+type anyPlaceholderQuery struct {
+	query CompiledQuery
+}
+
+func (q *anyPlaceholderQuery) Match(actualSQL string) error {
+	rebound, _, err := questionPlaceholders(actualSQL)
+	if err != nil {
+		return fmt.Errorf("could not tokenize actual sql: %s", err)
+	}
+	return q.query.Match(rebound)
+}
+
+func (q *anyPlaceholderQuery) matchArgs(actualSQL string, args int) error {
+	_, placeholders, err := questionPlaceholders(actualSQL)
+	if err != nil {
+		return fmt.Errorf("could not tokenize actual sql: %s", err)
+	}
+	if placeholders != args {
+		return fmt.Errorf("sql has %d placeholders, but it was called with %d arguments", placeholders, args)
+	}
+	return nil
+}
+
+// argsQuery is implemented by compiled queries which also verify
+// the placeholders of actual SQL against the given arguments.
+type argsQuery interface {
+	matchArgs(actualSQL string, args int) error
+}
+
+// questionPlaceholders rewrites every bind variable of sql to a question
+// mark and counts the distinct arguments they refer to: every question
+// mark, the highest dollar ordinal and every distinct name.
+func questionPlaceholders(sql string) (string, int, error) {
+	tokens, err := tokenizeSQL(sql)
+	if err != nil {
+		return sql, 0, err
+	}
+
+	s := []rune(sql)
+	var sb strings.Builder
+	var last, questions, ordinals int
+	names := make(map[string]bool)
+	for _, t := range tokens {
+		if t.kind != sqlPlaceholder {
+			continue
+		}
+		switch {
+		case t.value == "?":
+			questions++
+		case t.value[0] == '$':
+			n, err := strconv.Atoi(t.value[1:])
+			if err != nil {
+				continue // not an ordinal, like $name in some dialects
+			}
+			if n > ordinals {
+				ordinals = n
+			}
+		default:
+			names[t.value[1:]] = true
+		}
+		sb.WriteString(string(s[last:t.start]))
+		sb.WriteByte('?')
+		last = t.end
+	}
+	sb.WriteString(string(s[last:]))
+	return sb.String(), questions + ordinals + len(names), nil
+}
+
+// anyPlaceholderPattern escapes sql like regexp.QuoteMeta does, except
+// that its bind variables match a bind variable of any style.
+func anyPlaceholderPattern(sql string) string {
+	tokens, err := tokenizeSQL(sql)
+	if err != nil {
+		return regexp.QuoteMeta(sql)
+	}
+
+	s := []rune(sql)
+	var sb strings.Builder
+	var last int
+	for _, t := range tokens {
+		if t.kind != sqlPlaceholder {
+			continue
+		}
+		sb.WriteString(regexp.QuoteMeta(string(s[last:t.start])))
+		sb.WriteString(`(?:\?|\$\d+|[:@]\w+)`)
+		last = t.end
+	}
+	sb.WriteString(regexp.QuoteMeta(string(s[last:])))
+	return sb.String()
+}
@@ -0,0 +1,140 @@
+package sqlmock
+
+import (
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func TestQuestionPlaceholders(t *testing.T) {
+	cases := []struct {
+		sql          string
+		rebound      string
+		placeholders int
+	}{
+		{"SELECT * FROM users WHERE id = ? AND name = ?", "SELECT * FROM users WHERE id = ? AND name = ?", 2},
+		{"SELECT * FROM users WHERE id = $1 AND (name = $2 OR nick = $2)", "SELECT * FROM users WHERE id = ? AND (name = ? OR nick = ?)", 2},
+		{"SELECT * FROM users WHERE id = :id AND name = :name", "SELECT * FROM users WHERE id = ? AND name = ?", 2},
+		{"SELECT * FROM users WHERE id = @p1", "SELECT * FROM users WHERE id = ?", 1},
+		{"SELECT @@version", "SELECT @@version", 0},
+		{"SELECT id::text FROM users WHERE name = '?' AND id = $1 -- :comment", "SELECT id::text FROM users WHERE name = '?' AND id = ? -- :comment", 1},
+	}
+
+	for i, c := range cases {
+		rebound, placeholders, err := questionPlaceholders(c.sql)
+		if err != nil {
+			t.Errorf("unexpected error %q at %d case", err, i)
+			continue
+		}
+		if rebound != c.rebound {
+			t.Errorf("expected sql %q, but got %q at %d case", c.rebound, rebound, i)
+		}
+		if placeholders != c.placeholders {
+			t.Errorf("expected %d placeholders, but got %d at %d case", c.placeholders, placeholders, i)
+		}
+	}
+}
+
+func TestQueryMatcherAnyPlaceholderRebind(t *testing.T) {
+	t.Parallel()
+	db, mock, err := Newx(QueryMatcherOption(QueryMatcherAnyPlaceholder(QueryMatcherEqual)))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	query := "UPDATE users SET name = ? WHERE id = ?"
+	for _, bindType := range []int{sqlx.QUESTION, sqlx.DOLLAR, sqlx.NAMED, sqlx.AT} {
+		mock.ExpectExec(query).WithArgs("john", 1).WillReturnResult(NewResult(0, 1))
+		if _, err := db.Exec(sqlx.Rebind(bindType, query), "john", 1); err != nil {
+			t.Fatalf("unexpected error with bind type %d: %s", bindType, err)
+		}
+	}
+
+	mock.ExpectExec("UPDATE users SET name = $1 WHERE id = $2").WithArgs("john", 1).WillReturnResult(NewResult(0, 1))
+	if _, err := db.Exec(query, "john", 1); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	mock.ExpectExec(query).WillReturnResult(NewResult(0, 1))
+	_, err = db.Exec("UPDATE users SET name = $1 WHERE id = $2", "john")
+	if err == nil || err.Error() != "ExecQuery 'UPDATE users SET name = $1 WHERE id = $2', sql has 2 placeholders, but it was called with 1 arguments" {
+		t.Fatalf("expected placeholder count error, but got: %v", err)
+	}
+}
+
+func TestQueryMatcherAnyPlaceholderFixtures(t *testing.T) {
+	SetMockLocationByManual("./mock")
+	mocker, err := NewMocker(NewMockerOptions(
+		WithMockOptions(MockOptions{
+			ConfigSubFolder: "/placeholder",
+			ConfigFile:      []string{"select_hotels.json"},
+			SqlmockOptions:  []func(*sqlmock) error{QueryMatcherOption(QueryMatcherAnyPlaceholder(QueryMatcherRegexp))},
+		}),
+	))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer mocker.Close()
+
+	rows, err := mocker.Query("SELECT id, name FROM hotels WHERE city = $1 AND rating >= $2;", "New York", 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		names = append(names, name)
+	}
+	if len(names) != 2 || names[0] != "Grand Hotel" || names[1] != "Luxury Inn" {
+		t.Fatalf("unexpected hotels: %v", names)
+	}
+}
+
+func TestAnyPlaceholderFixture(t *testing.T) {
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	SetMockLocationByManual("./mock")
+	if err := LoadMockConfig(mock, "/placeholder", "select_any_placeholder.json"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var id int
+	var name string
+	err = db.QueryRow("SELECT id, name FROM hotels WHERE city = $1 AND rating >= $2;", "Miami", 4).Scan(&id, &name)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if id != 5 || name != "Luxury Resort" {
+		t.Fatalf("unexpected hotel: %d %s", id, name)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAnyPlaceholderPattern(t *testing.T) {
+	pattern := anyPlaceholderPattern("SELECT * FROM users WHERE id = ? AND name = '?'")
+	for _, sql := range []string{
+		"SELECT * FROM users WHERE id = ? AND name = '?'",
+		"SELECT * FROM users WHERE id = $1 AND name = '?'",
+		"SELECT * FROM users WHERE id = :id AND name = '?'",
+	} {
+		if err := QueryMatcherRegexp.Match(pattern, sql); err != nil {
+			t.Errorf("expected %q to match, but got: %s", sql, err)
+		}
+	}
+	if err := QueryMatcherRegexp.Match(pattern, "SELECT * FROM users WHERE id = $1 AND name = $2"); err == nil {
+		t.Error("expected a placeholder not to match a string literal")
+	}
+}
@@ -121,3 +121,37 @@ func TestQueryMatcherEqual(t *testing.T) {
 		}
 	}
 }
+
+func TestQueryMatcherPerExpectation(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New(QueryMatcherOption(QueryMatcherEqual))
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT * FROM users").WillReturnRows(NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("SELECT (.+) FROM articles WHERE created_at > (.+)").
+		MatchWith(QueryMatcherRegexp).
+		WillReturnRows(NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectPrepare("UPDATE articles SET (.+)").MatchWith(QueryMatcherRegexp).
+		ExpectExec().WillReturnResult(NewResult(0, 1))
+
+	if _, err := db.Query("SELECT * FROM users"); err != nil {
+		t.Errorf("error '%s' was not expected, while matching equal sql", err)
+	}
+	if _, err := db.Query("SELECT id, title FROM articles WHERE created_at > NOW() - 1"); err != nil {
+		t.Errorf("error '%s' was not expected, while matching sql with a regular expression", err)
+	}
+	stmt, err := db.Prepare("UPDATE articles SET title = ?")
+	if err != nil {
+		t.Fatalf("error '%s' was not expected, while preparing sql with a regular expression", err)
+	}
+	if _, err := stmt.Exec("title"); err != nil {
+		t.Errorf("error '%s' was not expected, while executing a statement matched by a regular expression", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,146 @@
+package sqlmock
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// resource is rows, a prepared statement or a transaction,
+// which was opened through the mock and has to be closed.
+type resource struct {
+	kind   string
+	query  string
+	stack  string
+	done   bool
+	closed func() bool // reports whether it was closed, unless done
+}
+
+func (r *resource) isClosed() bool {
+	return r.done || (r.closed != nil && r.closed())
+}
+
+func (r *resource) String() string {
+	msg := r.kind
+	if r.query != "" {
+		msg += fmt.Sprintf(" of query '%s'", r.query)
+	}
+	return msg + " opened at:" + r.stack
+}
+
+// resources keeps the resources opened, see StrictResourcesOption.
+type resources struct {
+	sync.Mutex
+	strict bool
+	opened []*resource
+}
+
+// StrictResourcesOption determines whether ExpectationsWereMet reports
+// every rows which were not closed, every prepared statement which was
+// not closed and every transaction which was neither committed nor
+// rolled back, with the stack of calls which opened it. That does not
+// need RowsWillBeClosed or WillBeClosed to be expected.
+//
+// Rows are closed by database/sql once all of them were read, so only
+// rows which were left unread, like after an early return, are reported.
+func StrictResourcesOption(strict bool) func(*sqlmock) error {
+	return func(s *sqlmock) error {
+		s.resources.strict = strict
+		return nil
+	}
+}
+
+// track keeps a resource which was opened, if resources are strict.
+func (c *sqlmock) track(kind, query string, closed func() bool) {
+	if !c.resources.strict {
+		return
+	}
+	r := &resource{kind: kind, query: query, stack: callSite(), closed: closed}
+	c.resources.Lock()
+	c.resources.opened = append(c.resources.opened, r)
+	c.resources.Unlock()
+}
+
+// openRows tracks the rows the query expectation returns,
+// together with the transaction they were queried in.
+func (c *sqlmock) openRows(ex *ExpectedQuery, query string) {
+	tx := c.transaction()
+	ex.Lock()
+	ex.tx = tx
+	ex.Unlock()
+
+	c.track("rows", query, func() bool {
+		ex.Lock()
+		defer ex.Unlock()
+		return ex.rowsWereClosed
+	})
+}
+
+// openStatement tracks a prepared statement.
+func (c *sqlmock) openStatement(stmt *statement) {
+	c.track("prepared statement", stmt.query, func() bool {
+		return stmt.closed
+	})
+}
+
+// endTransaction closes the transaction opened last, since
+// it is finished by Commit or Rollback, even if they fail.
+func (c *sqlmock) endTransaction() {
+	c.resources.Lock()
+	defer c.resources.Unlock()
+	for i := len(c.resources.opened) - 1; i >= 0; i-- {
+		if r := c.resources.opened[i]; r.kind == "transaction" && !r.done {
+			r.done = true
+			return
+		}
+	}
+}
+
+// leakedResources returns an error listing the resources which were not closed.
+func (c *sqlmock) leakedResources() error {
+	c.resources.Lock()
+	defer c.resources.Unlock()
+
+	var leaked []string
+	for _, r := range c.resources.opened {
+		if !r.isClosed() {
+			leaked = append(leaked, "\n  - "+r.String())
+		}
+	}
+	if len(leaked) == 0 {
+		return nil
+	}
+	return fmt.Errorf("there are %d resources which were not closed:%s", len(leaked), strings.Join(leaked, ""))
+}
+
+// path of this package, to skip its frames in call sites
+var packagePath = reflect.TypeOf(sqlmock{}).PkgPath()
+
+// callSite formats the stack of calls which led to the mock,
+// without the frames of the mock, of database/sql and of sqlx.
+func callSite() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	var sb strings.Builder
+	for {
+		frame, more := frames.Next()
+		if !internalFrame(frame) {
+			fmt.Fprintf(&sb, "\n      %s\n        %s:%d", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
+
+func internalFrame(frame runtime.Frame) bool {
+	for _, prefix := range []string{"runtime.", "testing.", "database/sql.", "github.com/jmoiron/sqlx."} {
+		if strings.HasPrefix(frame.Function, prefix) {
+			return true
+		}
+	}
+	return strings.HasPrefix(frame.Function, packagePath+".") && !strings.HasSuffix(frame.File, "_test.go")
+}
@@ -0,0 +1,88 @@
+package sqlmock
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStrictResourcesReportsLeaks(t *testing.T) {
+	db, mock, err := New(StrictResourcesOption(true))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM users").WillReturnRows(NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectPrepare("UPDATE users")
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	rows, err := tx.Query("SELECT id FROM users")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	rows.Next() // left unread and unclosed
+	if _, err := tx.Prepare("UPDATE users SET name = ?"); err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+
+	err = mock.ExpectationsWereMet()
+	if err == nil {
+		t.Fatal("expected the leaked resources to be reported")
+	}
+	for _, leak := range []string{
+		"there are 3 resources which were not closed:",
+		"  - transaction opened at:",
+		"  - rows of query 'SELECT id FROM users' opened at:",
+		"  - prepared statement of query 'UPDATE users SET name = ?' opened at:",
+		"TestStrictResourcesReportsLeaks",
+		"resources_test.go:",
+	} {
+		if !strings.Contains(err.Error(), leak) {
+			t.Errorf("expected %q to be reported, but got: %s", leak, err)
+		}
+	}
+	if strings.Contains(err.Error(), "database/sql.") {
+		t.Errorf("expected database/sql frames to be skipped, but got: %s", err)
+	}
+	rows.Close()
+}
+
+func TestStrictResourcesClosed(t *testing.T) {
+	db, mock, err := New(StrictResourcesOption(true))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM users").WillReturnRows(NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectPrepare("UPDATE users").ExpectExec().WillReturnResult(NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	var id int
+	if err := tx.QueryRow("SELECT id FROM users").Scan(&id); err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	stmt, err := tx.Prepare("UPDATE users SET name = ?")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	if _, err := stmt.Exec("john"); err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
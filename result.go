@@ -2,6 +2,7 @@ package sqlmock
 
 import (
 	"database/sql/driver"
+	"fmt"
 )
 
 // Result satisfies sql driver Result, which
@@ -37,3 +38,31 @@ func (r *result) LastInsertId() (int64, error) {
 func (r *result) RowsAffected() (int64, error) {
 	return r.rowsAffected, r.err
 }
+
+// NewResultSequence creates a sql driver Result for every
+// execution of a statement repeated in a loop, the first one
+// having firstInsertID as last insert id, which is incremented
+// for the following ones, each having the next of rowsAffected.
+// See ExpectedExec.WillReturnResults.
+func NewResultSequence(firstInsertID int64, rowsAffected ...int64) []driver.Result {
+	results := make([]driver.Result, len(rowsAffected))
+	for i, affected := range rowsAffected {
+		results[i] = NewResult(firstInsertID+int64(i), affected)
+	}
+	return results
+}
+
+// statusString prints the status a CALL statement ends with.
+func statusString(res driver.Result) string {
+	return "status - " + resultString(res)
+}
+
+// resultString prints a result on a single line.
+func resultString(res driver.Result) string {
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Sprintf("Error: %s", err)
+	}
+	affected, _ := res.RowsAffected()
+	return fmt.Sprintf("LastInsertId: %d, RowsAffected: %d", id, affected)
+}
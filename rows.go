@@ -23,32 +23,51 @@ var CSVColumnParser = func(s string) []byte {
 }
 
 type rowSets struct {
-	sets []*Rows
-	pos  int
-	ex   *ExpectedQuery
-	raw  [][]byte
+	sets   []*Rows
+	pos    int
+	ex     *ExpectedQuery
+	raw    [][]byte
+	closed bool
+	eof    bool // the current set returned io.EOF
 }
 
 func (rs *rowSets) Columns() []string {
+	rs.checkColumns()
 	return rs.sets[rs.pos].cols
 }
 
 func (rs *rowSets) Close() error {
+	rs.closed = true
 	rs.invalidateRaw()
+	rs.ex.rowsUnread = rs.unread()
+	for _, set := range rs.sets {
+		set.release()
+	}
 	rs.ex.rowsWereClosed = true
 	return rs.sets[rs.pos].closeErr
 }
 
 // advances to next row
 func (rs *rowSets) Next(dest []driver.Value) error {
+	if err := rs.checkNext(len(dest)); err != nil {
+		return err
+	}
 	r := rs.sets[rs.pos]
+	if r.setErr != nil {
+		return r.setErr
+	}
 	r.pos++
 	rs.invalidateRaw()
-	if r.pos > len(r.rows) {
-		return io.EOF // per interface spec
+	row, err := r.row(r.pos - 1)
+	if err == io.EOF {
+		rs.eof = true
+	}
+	if err != nil {
+		return err // io.EOF per interface spec, or an error of the row source
 	}
 
-	for i, col := range r.rows[r.pos-1] {
+	for i, col := range row {
+		col = rs.mock().convertRowValue(col)
 		if b, ok := rawBytes(col); ok {
 			rs.raw = append(rs.raw, b)
 			dest[i] = b
@@ -67,24 +86,65 @@ func (rs *rowSets) String() string {
 	}
 
 	msg := "should return rows:\n"
-	if len(rs.sets) == 1 {
+	if len(rs.sets) == 1 && rs.sets[0].status == nil && rs.sets[0].setErr == nil {
+		if rs.sets[0].source != nil {
+			return msg + "    rows generated on demand"
+		}
+		if rs.sets[0].table {
+			return msg + rs.sets[0].tableString("    ")
+		}
 		for n, row := range rs.sets[0].rows {
 			msg += fmt.Sprintf("    row %d - %+v\n", n, row)
 		}
 		return strings.TrimSpace(msg)
 	}
 	for i, set := range rs.sets {
+		if set.status != nil {
+			msg += "    " + statusString(set.status) + "\n"
+			continue
+		}
 		msg += fmt.Sprintf("    result set: %d\n", i)
-		for n, row := range set.rows {
-			msg += fmt.Sprintf("      row %d - %+v\n", n, row)
+		switch {
+		case set.setErr != nil:
+			msg += fmt.Sprintf("      should fail with error: %s\n", set.setErr)
+		case set.source != nil:
+			msg += "      rows generated on demand\n"
+		case set.emptySet:
+			msg += "      empty result set\n"
+		case set.table:
+			msg += set.tableString("      ") + "\n"
+		default:
+			for n, row := range set.rows {
+				msg += fmt.Sprintf("      row %d - %+v\n", n, row)
+			}
+		}
+		if set.nextSetErr != nil {
+			msg += fmt.Sprintf("    next result set should fail with error: %s\n", set.nextSetErr)
 		}
 	}
 	return strings.TrimSpace(msg)
 }
 
+// unread describes the first result set with rows which were not read,
+// it is empty if all of them were. Sets which failed count as read.
+func (rs *rowSets) unread() string {
+	for i, set := range rs.sets {
+		if set.status != nil || set.setErr != nil || set.exhausted {
+			continue
+		}
+		if set.source != nil {
+			return fmt.Sprintf("only %d rows of result set %d, generated on demand, were read", set.pos, i)
+		}
+		if set.pos < len(set.rows) {
+			return fmt.Sprintf("only %d of %d rows of result set %d were read", set.pos, len(set.rows), i)
+		}
+	}
+	return ""
+}
+
 func (rs *rowSets) empty() bool {
 	for _, set := range rs.sets {
-		if len(set.rows) > 0 {
+		if len(set.rows) > 0 || set.source != nil || set.status != nil || set.setErr != nil || set.nextSetErr != nil {
 			return false
 		}
 	}
@@ -118,15 +178,32 @@ func (rs *rowSets) invalidateRaw() {
 // Rows is a mocked collection of rows to
 // return for Query result
 type Rows struct {
-	converter driver.ValueConverter
-	cols      []string
-	def       []*Column
-	rows      [][]driver.Value
-	pos       int
-	nextErr   map[int]error
-	closeErr  error
+	converter  driver.ValueConverter
+	cols       []string
+	def        []*Column
+	rows       [][]driver.Value
+	pos        int
+	nextErr    map[int]error
+	closeErr   error
+	setErr     error         // returned instead of any row of the set
+	nextSetErr error         // returned when advancing to the next result set
+	emptySet   bool          // explicitly expected to have no rows, see NewEmptyRows
+	status     driver.Result // set for the status ending the result sets of a CALL
+	table      bool          // printed as a table, since it was built with FromTable
+
+	// lazily generated rows, see NewRowsFromFunc
+	source    RowsFunc
+	exhausted bool
+	buf       []driver.Value
+	stop      func()
 }
 
+// RowsFunc produces the values of the row at the given
+// zero based position when it is read. It must return
+// io.EOF once there are no more rows, any other error
+// is returned by rows.Next for that row.
+type RowsFunc func(row int) ([]driver.Value, error)
+
 // NewRows allows Rows to be created from a
 // sql driver.Value slice or from the CSV string and
 // to be used as sql driver.Rows.
@@ -139,6 +216,45 @@ func NewRows(columns []string) *Rows {
 	}
 }
 
+// NewRowsFromFunc allows Rows to be created from a RowsFunc,
+// which generates every row only when it is read. Nothing is
+// kept in memory, so it can simulate result sets far larger
+// than what AddRow could hold, for pagination, streaming or
+// benchmarks of scanning code.
+// Use Sqlmock.NewRowsFromFunc instead if using a custom converter
+func NewRowsFromFunc(columns []string, fn RowsFunc) *Rows {
+	r := NewRows(columns)
+	r.source = fn
+	return r
+}
+
+// NewEmptyRows allows Rows to be created for a result set which
+// is expected to have no rows, like an empty set in the middle of
+// the sets of a multi statement query. It is printed as an empty
+// result set, and adding a row to it panics.
+func NewEmptyRows(columns []string) *Rows {
+	r := NewRows(columns)
+	r.emptySet = true
+	return r
+}
+
+// SetError allows to set an error which will be returned
+// by rows.Next as soon as this result set is read, before
+// any of its rows, so that reading the set fails as a whole
+func (r *Rows) SetError(err error) *Rows {
+	r.setErr = err
+	return r
+}
+
+// NextResultSetError allows to set an error which will be
+// returned by rows.NextResultSet when advancing from this
+// result set to the next one, so that the following sets
+// can not be read
+func (r *Rows) NextResultSetError(err error) *Rows {
+	r.nextSetErr = err
+	return r
+}
+
 // CloseError allows to set an error
 // which will be returned by rows.Close
 // function.
@@ -167,6 +283,9 @@ func (r *Rows) AddRow(values ...driver.Value) *Rows {
 	if len(values) != len(r.cols) {
 		panic("Expected number of values to match number of columns")
 	}
+	if r.emptySet {
+		panic("Expected no rows to be added to an empty result set")
+	}
 
 	row := make([]driver.Value, len(r.cols))
 	for i, v := range values {
@@ -188,19 +307,75 @@ func (r *Rows) AddRow(values ...driver.Value) *Rows {
 	return r
 }
 
+// row returns the values of the row at the given position,
+// generating and converting them for rows created from a RowsFunc.
+func (r *Rows) row(n int) ([]driver.Value, error) {
+	if r.source == nil {
+		if n >= len(r.rows) {
+			return nil, io.EOF
+		}
+		return r.rows[n], nil
+	}
+
+	if r.exhausted {
+		return nil, io.EOF
+	}
+	values, err := r.source(n)
+	if err == io.EOF {
+		r.exhausted = true
+		r.release()
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != len(r.cols) {
+		return nil, fmt.Errorf("row #%d has %d values, but there are %d columns", n+1, len(values), len(r.cols))
+	}
+
+	// the generated row is copied to the destination by rows.Next,
+	// so a single buffer can be reused for the whole result set
+	if r.buf == nil {
+		r.buf = make([]driver.Value, len(r.cols))
+	}
+	for i, v := range values {
+		if r.buf[i], err = r.converter.ConvertValue(v); err != nil {
+			return nil, fmt.Errorf("row #%d, column #%d (%q) type %T: %s", n+1, i, r.cols[i], v, err)
+		}
+	}
+	return r.buf, nil
+}
+
+// release stops the iterator behind lazily generated rows, if any.
+func (r *Rows) release() {
+	if r.stop != nil {
+		r.stop()
+		r.stop = nil
+	}
+}
+
 // FromCSVString build rows from csv string.
 // return the same instance to perform subsequent actions.
 // Note that the number of values must match the number
-// of columns
+// of columns, it panics otherwise. See FromCSVReader
+// for typed values and multiple result sets.
 func (r *Rows) FromCSVString(s string) *Rows {
 	res := strings.NewReader(strings.TrimSpace(s))
 	csvReader := csv.NewReader(res)
+	csvReader.FieldsPerRecord = -1
 
 	for {
 		res, err := csvReader.Read()
-		if err != nil || res == nil {
+		if err == io.EOF {
 			break
 		}
+		if err != nil {
+			panic(fmt.Errorf("could not read csv: %s", err))
+		}
+		if len(res) != len(r.cols) {
+			line, _ := csvReader.FieldPos(0)
+			panic(fmt.Errorf("csv line %d has %d values, but there are %d columns", line, len(res), len(r.cols)))
+		}
 
 		row := make([]driver.Value, len(r.cols))
 		for i, v := range res {
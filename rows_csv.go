@@ -0,0 +1,205 @@
+package sqlmock
+
+import (
+	"bufio"
+	"database/sql/driver"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVOptions configures how FromCSVReader and FromCSVFile read typed CSV.
+type CSVOptions struct {
+	// Null is the value read as NULL, compared case insensitively,
+	// "NULL" by default.
+	Null string
+	// Comma is the field delimiter, ',' by default.
+	Comma rune
+	// SetSeparator is the line separating result sets, "---" by default.
+	SetSeparator string
+}
+
+// FromCSVFile reads result sets of typed CSV from the file at path,
+// see FromCSVReader for the format.
+func FromCSVFile(path string, opts CSVOptions) ([]*Rows, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return FromCSVReader(f, opts)
+}
+
+// FromCSVReader reads result sets of typed CSV, to be returned by
+// ExpectedQuery.WillReturnRows. Every set starts with a header naming
+// the columns, optionally followed by their type:
+//
+//	id:int64,price:float64,created:time(RFC3339),name
+//	1,9.99,2023-01-02T03:04:05Z,john
+//	2,NULL,2023-01-02T03:04:05Z,"doe, jane"
+//	---
+//	total:int64
+//	2
+//
+// The types are int64, float64, bool, string, bytes and time(layout),
+// where layout is a layout of the time package, or the name of one of
+// its constants like RFC3339 or DateTime. Columns without a type are
+// bytes, like FromCSVString reads them. Every row must have a value
+// for every column of the header.
+func FromCSVReader(r io.Reader, opts CSVOptions) ([]*Rows, error) {
+	if opts.Null == "" {
+		opts.Null = "NULL"
+	}
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+	if opts.SetSeparator == "" {
+		opts.SetSeparator = "---"
+	}
+
+	var sets []*Rows
+	var chunk strings.Builder
+	var line, start int
+	flush := func() error {
+		if strings.TrimSpace(chunk.String()) != "" {
+			set, err := readTypedCSV(chunk.String(), start, opts)
+			if err != nil {
+				return fmt.Errorf("result set %d: %s", len(sets), err)
+			}
+			sets = append(sets, set)
+		}
+		chunk.Reset()
+		start = line
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == opts.SetSeparator {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		chunk.WriteString(scanner.Text())
+		chunk.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if len(sets) == 0 {
+		return nil, fmt.Errorf("csv has no header")
+	}
+	return sets, nil
+}
+
+// csvColumnType parses the text of a CSV value to a driver.Value.
+type csvColumnType func(s string) (driver.Value, error)
+
+// readTypedCSV reads a single result set, offset is the number
+// of lines before it, so that errors refer to the whole input.
+func readTypedCSV(s string, offset int, opts CSVOptions) (*Rows, error) {
+	reader := csv.NewReader(strings.NewReader(s))
+	reader.Comma = opts.Comma
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("could not read header: %s", err)
+	}
+	columns := make([]string, len(header))
+	types := make([]csvColumnType, len(header))
+	for i, h := range header {
+		name, typ, err := parseCSVHeader(strings.TrimSpace(h))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", offset+1, err)
+		}
+		columns[i], types[i] = name, typ
+	}
+
+	rows := NewRows(columns)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if len(record) != len(columns) {
+			return nil, fmt.Errorf("line %d: expected %d values, but got %d", offset+line, len(columns), len(record))
+		}
+		row := make([]driver.Value, len(columns))
+		for i, v := range record {
+			v = strings.TrimSpace(v)
+			if strings.EqualFold(v, opts.Null) {
+				continue
+			}
+			if row[i], err = types[i](v); err != nil {
+				return nil, fmt.Errorf("line %d, column %q: %s", offset+line, columns[i], err)
+			}
+		}
+		rows.rows = append(rows.rows, row)
+	}
+	return rows, nil
+}
+
+// parseCSVHeader parses a header like "created:time(RFC3339)".
+func parseCSVHeader(h string) (string, csvColumnType, error) {
+	name, typ, typed := strings.Cut(h, ":")
+	if !typed {
+		return name, func(s string) (driver.Value, error) { return []byte(s), nil }, nil
+	}
+	switch typ {
+	case "int64":
+		return name, func(s string) (driver.Value, error) { return strconv.ParseInt(s, 10, 64) }, nil
+	case "float64":
+		return name, func(s string) (driver.Value, error) { return strconv.ParseFloat(s, 64) }, nil
+	case "bool":
+		return name, func(s string) (driver.Value, error) { return strconv.ParseBool(s) }, nil
+	case "string":
+		return name, func(s string) (driver.Value, error) { return s, nil }, nil
+	case "bytes":
+		return name, func(s string) (driver.Value, error) { return []byte(s), nil }, nil
+	}
+	if strings.HasPrefix(typ, "time(") && strings.HasSuffix(typ, ")") {
+		layout := typ[len("time(") : len(typ)-1]
+		if named, ok := timeLayouts[layout]; ok {
+			layout = named
+		}
+		return name, func(s string) (driver.Value, error) { return time.Parse(layout, s) }, nil
+	}
+	return "", nil, fmt.Errorf("unknown type %q of column %q", typ, name)
+}
+
+// layouts of the time package by the names of their constants
+var timeLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"Stamp":       time.Stamp,
+	"StampMilli":  time.StampMilli,
+	"StampMicro":  time.StampMicro,
+	"StampNano":   time.StampNano,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
@@ -0,0 +1,78 @@
+package sqlmock
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFromCSVFile(t *testing.T) {
+	sets, err := FromCSVFile("./mock/csv/hotels.csv", CSVOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(sets) != 2 {
+		t.Fatalf("expected 2 result sets, but got %d", len(sets))
+	}
+
+	hotels := sets[0]
+	if expected := []string{"id", "name", "rating", "open", "created", "notes"}; !reflect.DeepEqual(hotels.cols, expected) {
+		t.Fatalf("expected columns %v, but got %v", expected, hotels.cols)
+	}
+	created := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expected := [][]interface{}{
+		{int64(1), "Grand Hotel", 4.5, true, created, []byte("pool, spa")},
+		{int64(2), "Luxury Inn", nil, false, time.Date(2022, 12, 31, 23, 59, 59, 0, time.UTC), nil},
+	}
+	for i, row := range expected {
+		for j, v := range row {
+			if !reflect.DeepEqual(hotels.rows[i][j], v) && !(v == nil && hotels.rows[i][j] == nil) {
+				t.Errorf("expected %#v at row %d, column %d, but got %#v", v, i, j, hotels.rows[i][j])
+			}
+		}
+	}
+
+	if total := sets[1]; len(total.rows) != 1 || total.rows[0][0] != int64(2) {
+		t.Fatalf("unexpected second result set: %v", total.rows)
+	}
+}
+
+func TestFromCSVReaderOptions(t *testing.T) {
+	csv := "id:int64;name:string\n1;-\n===\nid\n2"
+	sets, err := FromCSVReader(strings.NewReader(csv), CSVOptions{Null: "-", Comma: ';', SetSeparator: "==="})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(sets) != 2 || sets[0].rows[0][1] != nil || string(sets[1].rows[0][0].([]byte)) != "2" {
+		t.Fatalf("unexpected result sets: %v and %v", sets[0].rows, sets[1].rows)
+	}
+}
+
+func TestFromCSVReaderErrors(t *testing.T) {
+	cases := []struct {
+		csv string
+		err string
+	}{
+		{"id:int64,name\n1,john\n2", "result set 0: line 3: expected 2 values, but got 1"},
+		{"id:int64\n1\n---\nid:int64\nx", `result set 1: line 5, column "id": strconv.ParseInt: parsing "x": invalid syntax`},
+		{"id:uuid\n1", `result set 0: line 1: unknown type "uuid" of column "id"`},
+		{"", "csv has no header"},
+	}
+
+	for i, c := range cases {
+		_, err := FromCSVReader(strings.NewReader(c.csv), CSVOptions{})
+		if err == nil || err.Error() != c.err {
+			t.Errorf("expected error %q, but got %v at %d case", c.err, err, i)
+		}
+	}
+}
+
+func TestFromCSVStringColumnCount(t *testing.T) {
+	defer func() {
+		if err := recover(); err == nil || err.(error).Error() != "csv line 2 has 1 values, but there are 2 columns" {
+			t.Fatalf("expected a column count panic, but got %v", err)
+		}
+	}()
+	NewRows([]string{"id", "title"}).FromCSVString("1,hello\n2")
+}
@@ -0,0 +1,40 @@
+//go:build go1.23
+
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"io"
+	"iter"
+)
+
+// NewRowsFromSeq allows Rows to be created from an iterator,
+// which is only advanced when the rows are read. The iterator
+// is stopped as soon as the rows are closed.
+func NewRowsFromSeq(columns []string, seq iter.Seq[[]driver.Value]) *Rows {
+	return NewRowsFromSeq2(columns, func(yield func([]driver.Value, error) bool) {
+		for values := range seq {
+			if !yield(values, nil) {
+				return
+			}
+		}
+	})
+}
+
+// NewRowsFromSeq2 is like NewRowsFromSeq, but the iterator may
+// also yield an error, which is returned by rows.Next for that row.
+func NewRowsFromSeq2(columns []string, seq iter.Seq2[[]driver.Value, error]) *Rows {
+	r := NewRows(columns)
+	var next func() ([]driver.Value, error, bool)
+	r.source = func(int) ([]driver.Value, error) {
+		if next == nil {
+			next, r.stop = iter.Pull2(seq)
+		}
+		values, err, ok := next()
+		if !ok {
+			return nil, io.EOF
+		}
+		return values, err
+	}
+	return r
+}
@@ -0,0 +1,77 @@
+//go:build go1.23
+
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"testing"
+)
+
+func TestRowsFromSeqMultipleResultSets(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	var stopped bool
+	ids := NewRowsFromSeq([]string{"id"}, func(yield func([]driver.Value) bool) {
+		defer func() { stopped = true }()
+		for i := 1; ; i++ {
+			if !yield([]driver.Value{i}) {
+				return
+			}
+		}
+	})
+	names := NewRowsFromSeq2([]string{"name"}, func(yield func([]driver.Value, error) bool) {
+		if !yield([]driver.Value{"gopher"}, nil) {
+			return
+		}
+		yield(nil, fmt.Errorf("name error"))
+	})
+	mock.ExpectQuery("SELECT").WillReturnRows(ids, names)
+
+	rs, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var id int
+	for i := 1; i <= 3; i++ {
+		if !rs.Next() {
+			t.Fatalf("expected row %d to be available in first result set", i)
+		}
+		if err := rs.Scan(&id); err != nil || id != i {
+			t.Fatalf("expected id %d, but got %d with error: %v", i, id, err)
+		}
+	}
+
+	if !rs.NextResultSet() {
+		t.Fatal("had to have next result set")
+	}
+
+	var name string
+	if !rs.Next() {
+		t.Fatal("expected a row to be available in second result set")
+	}
+	if err := rs.Scan(&name); err != nil || name != "gopher" {
+		t.Fatalf("expected name gopher, but got %q with error: %v", name, err)
+	}
+	if rs.Next() {
+		t.Fatal("expected next row to produce error")
+	}
+	if rs.Err() == nil || rs.Err().Error() != "name error" {
+		t.Fatalf("expected name error, but got: %v", rs.Err())
+	}
+
+	rs.Close()
+	if !stopped {
+		t.Fatal("expected the infinite iterator to be stopped when rows are closed")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
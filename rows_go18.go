@@ -18,14 +18,23 @@ func (rs *rowSets) NextResultSet() error {
 	if !rs.HasNextResultSet() {
 		return io.EOF
 	}
+	if err := rs.mock().checkNextResultSet(); err != nil {
+		return err
+	}
+	if err := rs.sets[rs.pos].nextSetErr; err != nil {
+		return err
+	}
 
 	rs.pos++
+	rs.eof = false
 	return nil
 }
 
-// type for rows with columns definition created with sqlmock.NewRowsWithColumnDefinition
+// type for rows with columns definition created with sqlmock.NewRowsWithColumnDefinition,
+// or else inferred from the values of the rows
 type rowSetsWithDefinition struct {
 	*rowSets
+	inferred [][]*Column
 }
 
 // Implement the "RowsColumnTypeDatabaseTypeName" interface
@@ -55,7 +64,21 @@ func (rs *rowSetsWithDefinition) ColumnTypeScanType(index int) reflect.Type {
 
 // return column definition from current set metadata
 func (rs *rowSetsWithDefinition) getDefinition(index int) *Column {
-	return rs.sets[rs.pos].def[index]
+	set := rs.sets[rs.pos]
+	if set.def != nil {
+		return set.def[index]
+	}
+	if rs.inferred == nil {
+		rs.inferred = make([][]*Column, len(rs.sets))
+	}
+	if rs.inferred[rs.pos] == nil {
+		var profile DialectProfile
+		if rs.ex != nil && rs.ex.mock != nil {
+			profile = rs.ex.mock.dialect
+		}
+		rs.inferred[rs.pos] = inferColumns(set, profile)
+	}
+	return rs.inferred[rs.pos][index]
 }
 
 // NewRowsWithColumnDefinition return rows with columns metadata
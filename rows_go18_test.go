@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -385,3 +386,142 @@ func TestNewColumnWithDefinition(t *testing.T) {
 		}
 	})
 }
+
+func TestQueryMultiRowsSetErrors(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	setErr := fmt.Errorf("second set failed")
+	mock.ExpectQuery("SELECT").WillReturnRows(
+		NewRows([]string{"id"}).AddRow(1),
+		NewRows([]string{"name"}).SetError(setErr),
+	)
+
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %v", err)
+	}
+	for rows.Next() {
+	}
+	if !rows.NextResultSet() {
+		t.Fatal("had to have next result set")
+	}
+	if rows.Next() {
+		t.Error("was not expecting a row in the failing result set")
+	}
+	if rows.Err() != setErr {
+		t.Errorf("expected error %v, but got %v", setErr, rows.Err())
+	}
+	rows.Close()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestQueryMultiRowsNextResultSetError(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	nextErr := fmt.Errorf("lost connection between sets")
+	mock.ExpectQuery("SELECT").WillReturnRows(
+		NewRows([]string{"id"}).AddRow(1).NextResultSetError(nextErr),
+		NewRows([]string{"name"}).AddRow("gopher"),
+	)
+
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+	}
+	if rows.NextResultSet() {
+		t.Error("was not expecting to advance to the next result set")
+	}
+	if rows.Err() != nextErr {
+		t.Errorf("expected error %v, but got %v", nextErr, rows.Err())
+	}
+}
+
+func TestQueryMultiRowsEmptyMiddleSet(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT").WillReturnRows(
+		NewRows([]string{"id"}).AddRow(1),
+		NewEmptyRows([]string{"id"}),
+		NewRows([]string{"id"}).AddRow(3),
+	)
+
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %v", err)
+	}
+	defer rows.Close()
+
+	var counts []int
+	for {
+		var n int
+		for rows.Next() {
+			n++
+		}
+		counts = append(counts, n)
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	if rows.Err() != nil {
+		t.Fatalf("error was not expected, but got: %v", rows.Err())
+	}
+	if !reflect.DeepEqual(counts, []int{1, 0, 1}) {
+		t.Errorf("expected rows per set [1 0 1], but got %v", counts)
+	}
+}
+
+func TestMultiRowsSetErrorsString(t *testing.T) {
+	t.Parallel()
+	rs := &rowSets{sets: []*Rows{
+		NewRows([]string{"id"}).AddRow(1).NextResultSetError(fmt.Errorf("broken pipe")),
+		NewEmptyRows([]string{"id"}),
+		NewRows([]string{"id"}).SetError(fmt.Errorf("deadlock")),
+	}}
+	expected := `should return rows:
+    result set: 0
+      row 0 - [1]
+    next result set should fail with error: broken pipe
+    result set: 1
+      empty result set
+    result set: 2
+      should fail with error: deadlock`
+	if s := rs.String(); s != expected {
+		t.Errorf("expected:\n%s\nbut got:\n%s", expected, s)
+	}
+
+	single := &rowSets{sets: []*Rows{NewRows([]string{"id"}).SetError(fmt.Errorf("deadlock"))}}
+	if s := single.String(); !strings.Contains(s, "should fail with error: deadlock") {
+		t.Errorf("expected the set error to be shown, but got: %s", s)
+	}
+}
+
+func TestEmptyRowsAddRowPanics(t *testing.T) {
+	t.Parallel()
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic when adding a row to an empty result set")
+		}
+	}()
+	NewEmptyRows([]string{"id"}).AddRow(1)
+}
@@ -0,0 +1,127 @@
+package sqlmock
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRowsMustBeFullyRead(t *testing.T) {
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT name FROM users").
+		WillReturnRows(NewRows([]string{"name"}).AddRow("john").AddRow("jane")).
+		RowsMustBeFullyRead()
+
+	var name string
+	if err := db.QueryRow("SELECT name FROM users").Scan(&name); err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+
+	err = mock.ExpectationsWereMet()
+	if err == nil || !strings.Contains(err.Error(), "expected query rows to be fully read, but only 1 of 2 rows of result set 0 were read") {
+		t.Errorf("expected the unread rows to be reported, but got: %v", err)
+	}
+}
+
+func TestRowsMustBeFullyReadQueryRow(t *testing.T) {
+	db, mock, err := New(RowsMustBeFullyReadOption(true))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT name FROM users").WillReturnRows(NewRows([]string{"name"}).AddRow("john"))
+
+	var name string
+	if err := db.QueryRow("SELECT name FROM users WHERE id = 1").Scan(&name); err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expected the only row read by QueryRow to meet the expectation, but got: %s", err)
+	}
+}
+
+func TestRowsMustBeFullyReadAllSets(t *testing.T) {
+	db, mock, err := New(RowsMustBeFullyReadOption(true))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT").WillReturnRows(
+		NewRows([]string{"id"}).AddRow(1),
+		NewRows([]string{"id"}).AddRow(2),
+	)
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	for rows.Next() {
+	}
+	rows.Close()
+
+	err = mock.ExpectationsWereMet()
+	if err == nil || !strings.Contains(err.Error(), "only 0 of 1 rows of result set 1 were read") {
+		t.Errorf("expected the unread result set to be reported, but got: %v", err)
+	}
+}
+
+func TestRowsMustBeFullyReadMet(t *testing.T) {
+	db, mock, err := New(RowsMustBeFullyReadOption(true))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT").WillReturnRows(
+		NewRows([]string{"id"}).AddRow(1).AddRow(2),
+		NewEmptyRows([]string{"id"}),
+	)
+	errBroken := errors.New("broken")
+	mock.ExpectQuery("SELECT broken").WillReturnError(errBroken)
+
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	for {
+		for rows.Next() {
+		}
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	rows.Close()
+	if _, err := db.Query("SELECT broken"); err != errBroken {
+		t.Fatalf("expected error %v, but got: %v", errBroken, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRowsMustBeFullyReadNotClosed(t *testing.T) {
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT").WillReturnRows(NewRows([]string{"id"}).AddRow(1)).RowsMustBeFullyRead()
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("error was not expected, but got: %s", err)
+	}
+	defer rows.Close()
+
+	err = mock.ExpectationsWereMet()
+	if err == nil || !strings.Contains(err.Error(), "but they were not closed") {
+		t.Errorf("expected the rows which were not closed to be reported, but got: %v", err)
+	}
+}
@@ -0,0 +1,231 @@
+package sqlmock
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// StructRowsOptions configures how NewRowsFromStructs maps struct fields to columns.
+type StructRowsOptions struct {
+	// ConvertCase names the columns of fields without a `db` tag, like
+	// ConvertStringFormats does with Case_Snake or Case_Lower. When it is
+	// zero, names are lower cased, which is the default of sqlx.
+	ConvertCase uint8
+	// Columns generates Column metadata from the field types, so that
+	// rows.ColumnTypes reports scan types, database types and nullability.
+	Columns bool
+}
+
+// NewRowsFromStructs allows Rows to be created from a slice of structs,
+// or of pointers to structs, with a row for every element and a column
+// for every field, named like sqlx maps columns to fields:
+//
+//   - the `db` tag names the column, `db:"-"` skips the field
+//   - fields of embedded structs are columns of the embedding struct
+//   - fields of other nested structs are named like "address.city"
+//   - nil pointers and invalid sql.Null* values are NULL
+//
+// It panics if slice is not a slice of structs.
+//
+//	rows := sqlmock.NewRowsFromStructs(hotels, sqlmock.StructRowsOptions{ConvertCase: sqlmock.Case_Snake})
+func NewRowsFromStructs(slice interface{}, opts StructRowsOptions) *Rows {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		panic(fmt.Errorf("expected a slice of structs, but got %T", slice))
+	}
+	t := derefType(v.Type().Elem())
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Errorf("expected a slice of structs, but got %T", slice))
+	}
+
+	fields := structFields(t, nil, "", opts.ConvertCase)
+	var r *Rows
+	if opts.Columns {
+		columns := make([]*Column, len(fields))
+		for i, f := range fields {
+			columns[i] = f.column()
+		}
+		r = NewRowsWithColumnDefinition(columns...)
+	} else {
+		names := make([]string, len(fields))
+		for i, f := range fields {
+			names[i] = f.name
+		}
+		r = NewRows(names)
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		for elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				panic(fmt.Errorf("element %d of %T is nil", i, slice))
+			}
+			elem = elem.Elem()
+		}
+		values := make([]driver.Value, len(fields))
+		for j, f := range fields {
+			values[j] = f.value(elem)
+		}
+		r.AddRow(values...)
+	}
+	return r
+}
+
+// structField is a field of a struct mapped to a column.
+type structField struct {
+	name  string
+	index []int
+	typ   reflect.Type
+}
+
+// structFields lists the fields of t mapped to columns, in the order of
+// declaration, descending into embedded and nested structs.
+func structFields(t reflect.Type, index []int, prefix string, convertCase uint8) []structField {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !(f.Anonymous && derefType(f.Type).Kind() == reflect.Struct) {
+			continue // unexported, but for the promoted fields of embedded structs
+		}
+		tag := strings.Split(f.Tag.Get("db"), ",")[0]
+		if tag == "-" {
+			continue
+		}
+
+		idx := append(append([]int(nil), index...), i)
+		ft := derefType(f.Type)
+		if ft.Kind() == reflect.Struct && !isValueStruct(ft) {
+			nested := prefix
+			if !f.Anonymous || tag != "" {
+				nested += structFieldName(f, tag, convertCase) + "."
+			}
+			fields = append(fields, structFields(ft, idx, nested, convertCase)...)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		fields = append(fields, structField{
+			name:  prefix + structFieldName(f, tag, convertCase),
+			index: idx,
+			typ:   f.Type,
+		})
+	}
+	return fields
+}
+
+func structFieldName(f reflect.StructField, tag string, convertCase uint8) string {
+	if tag != "" {
+		return tag
+	}
+	if convertCase == 0 {
+		return strings.ToLower(f.Name)
+	}
+	return ConvertStringFormats(f.Name, convertCase)
+}
+
+// value returns the value of the field in the struct v,
+// or nil if a pointer on its way is nil.
+func (f structField) value(v reflect.Value) driver.Value {
+	for _, i := range f.index {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+	if v.Kind() != reflect.Ptr && v.CanAddr() && v.Addr().Type().Implements(valuerType) {
+		v = v.Addr()
+	}
+	if valuer, ok := v.Interface().(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err != nil {
+			panic(fmt.Errorf("column %q: %s", f.name, err))
+		}
+		return value
+	}
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	return v.Interface()
+}
+
+// column describes the field as Column metadata.
+func (f structField) column() *Column {
+	t := derefType(f.typ)
+	sample := reflect.New(t).Elem().Interface()
+	if valueType, ok := nullValueType(t); ok {
+		return NewColumn(f.name).OfType(goDBType(valueType), sample).Nullable(true)
+	}
+	return NewColumn(f.name).OfType(goDBType(t), sample).Nullable(f.typ.Kind() == reflect.Ptr)
+}
+
+var (
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+// isValueStruct tells whether a struct is a single value of a column,
+// like time.Time or sql.NullString, rather than a set of columns.
+func isValueStruct(t reflect.Type) bool {
+	return t == timeType || t.Implements(valuerType) || reflect.PtrTo(t).Implements(scannerType)
+}
+
+// nullValueType returns the type of the value held by sql.Null* like
+// types, which have a Valid field and the value in another one.
+func nullValueType(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() != reflect.Struct || t.NumField() != 2 || !isValueStruct(t) {
+		return nil, false
+	}
+	valid, ok := t.FieldByName("Valid")
+	if !ok || valid.Type.Kind() != reflect.Bool {
+		return nil, false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if i != valid.Index[0] {
+			return t.Field(i).Type, true
+		}
+	}
+	return nil, false
+}
+
+// goDBType names the database type a value of the Go type is usually stored as.
+func goDBType(t reflect.Type) string {
+	if t == timeType {
+		return "TIMESTAMP"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "BOOLEAN"
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return "INT"
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return "BIGINT"
+	case reflect.Float32, reflect.Float64:
+		return "DOUBLE"
+	case reflect.String:
+		return "VARCHAR"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "BLOB"
+		}
+	}
+	return ""
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
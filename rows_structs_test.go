@@ -0,0 +1,102 @@
+package sqlmock
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/panhongrainbow/go-sqlxmock/genuine/testdata"
+)
+
+func TestRowsFromStructsRoundTrip(t *testing.T) {
+	t.Parallel()
+	db, mock, err := Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM hotel").
+		WillReturnRows(NewRowsFromStructs(testdata.HotelExample, StructRowsOptions{ConvertCase: Case_Lower}))
+
+	var hotels []testdata.Hotels
+	if err := db.Select(&hotels, "SELECT * FROM hotel"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(hotels, testdata.HotelExample) {
+		t.Fatalf("expected hotels to be scanned back, but got %+v", hotels)
+	}
+}
+
+type structRowsAudit struct {
+	CreatedAt time.Time  `db:"created_at"`
+	DeletedAt *time.Time `db:"deleted_at"`
+}
+
+type structRowsAddress struct {
+	City string
+}
+
+type structRowsUser struct {
+	structRowsAudit
+	ID       int64
+	Nick     sql.NullString
+	Score    *float64
+	Address  structRowsAddress `db:"addr"`
+	Password string            `db:"-"`
+	internal int
+}
+
+func TestRowsFromStructsFields(t *testing.T) {
+	created := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	score := 4.5
+	users := []*structRowsUser{
+		{structRowsAudit{CreatedAt: created}, 1, sql.NullString{String: "johnny", Valid: true}, &score, structRowsAddress{"Miami"}, "secret", 1},
+		{structRowsAudit{CreatedAt: created}, 2, sql.NullString{}, nil, structRowsAddress{"Denver"}, "secret", 2},
+	}
+
+	rows := NewRowsFromStructs(users, StructRowsOptions{ConvertCase: Case_Snake, Columns: true})
+	expectedCols := []string{"created_at", "deleted_at", "id", "nick", "score", "addr.city"}
+	if !reflect.DeepEqual(rows.cols, expectedCols) {
+		t.Fatalf("expected columns %v, but got %v", expectedCols, rows.cols)
+	}
+	if len(rows.rows) != 2 {
+		t.Fatalf("expected 2 rows, but got %d", len(rows.rows))
+	}
+	if row := rows.rows[0]; row[0] != created || row[1] != nil || row[2] != int64(1) || row[3] != "johnny" || row[4] != 4.5 || row[5] != "Miami" {
+		t.Fatalf("unexpected first row: %v", row)
+	}
+	if row := rows.rows[1]; row[3] != nil || row[4] != nil {
+		t.Fatalf("expected NULL values in the second row, but got %v", row)
+	}
+
+	defs := []struct {
+		dbType   string
+		nullable bool
+		scanType reflect.Type
+	}{
+		{"TIMESTAMP", false, reflect.TypeOf(time.Time{})},
+		{"TIMESTAMP", true, reflect.TypeOf(time.Time{})},
+		{"BIGINT", false, reflect.TypeOf(int64(0))},
+		{"VARCHAR", true, reflect.TypeOf(sql.NullString{})},
+		{"DOUBLE", true, reflect.TypeOf(float64(0))},
+		{"VARCHAR", false, reflect.TypeOf("")},
+	}
+	for i, def := range defs {
+		column := rows.def[i]
+		nullable, _ := column.IsNullable()
+		if column.DbType() != def.dbType || nullable != def.nullable || column.ScanType() != def.scanType {
+			t.Errorf("unexpected metadata of column %q: %s, nullable %t, %v", column.Name(), column.DbType(), nullable, column.ScanType())
+		}
+	}
+}
+
+func TestRowsFromStructsPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for a slice of ints")
+		}
+	}()
+	NewRowsFromStructs([]int{1}, StructRowsOptions{})
+}
@@ -0,0 +1,249 @@
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// FromTable build rows from a table, like the MySQL client prints it
+// or like it is written in Markdown, with a header naming the columns:
+//
+//	+----+-------------+--------+
+//	| id | name        | rating |
+//	+----+-------------+--------+
+//	|  1 | Grand Hotel |    4.5 |
+//	|  2 | Luxury Inn  |   NULL |
+//	+----+-------------+--------+
+//
+// Values are typed by column: int64 if all values of the column are
+// integers, otherwise float64 if they are numbers, bool if they are
+// true or false, time.Time if they are dates like 2006-01-02 15:04:05,
+// or else string. NULL is nil and a value in quotes is always a string.
+// Values are then converted like AddRow converts them.
+// Border lines, separator lines and lines not starting with | are skipped.
+//
+// If the rows have no columns yet, they are named by the header,
+// otherwise the header must name the same columns. It panics if the
+// table can not be parsed. Rows built from a table are printed as a
+// table in expectations and failure messages as well.
+func (r *Rows) FromTable(table string) *Rows {
+	var header []string
+	var cells [][]string
+	for n, line := range strings.Split(table, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "|") || isTableSeparator(line) {
+			continue
+		}
+		row := splitTableRow(line)
+		if header == nil {
+			header = row
+			continue
+		}
+		if len(row) != len(header) {
+			panic(fmt.Errorf("table line %d has %d values, but there are %d columns", n+1, len(row), len(header)))
+		}
+		cells = append(cells, row)
+	}
+	if header == nil {
+		panic(fmt.Errorf("table has no header"))
+	}
+
+	if len(r.cols) == 0 {
+		r.cols = header
+	} else if strings.Join(r.cols, "|") != strings.Join(header, "|") {
+		panic(fmt.Errorf("table header %v does not match columns %v", header, r.cols))
+	}
+
+	types := make([]tableColumnType, len(header))
+	for i := range header {
+		types[i] = inferTableColumn(cells, i)
+	}
+	for n, row := range cells {
+		values := make([]driver.Value, len(row))
+		for i, cell := range row {
+			v, err := r.converter.ConvertValue(types[i].parse(cell))
+			if err != nil {
+				panic(fmt.Errorf("table row #%d, column #%d (%q): %s", n+1, i, r.cols[i], err))
+			}
+			values[i] = v
+		}
+		r.rows = append(r.rows, values)
+	}
+	r.table = true
+	return r
+}
+
+// isTableSeparator tells whether the line is a border like +----+
+// or a Markdown separator like |---|:--:|.
+func isTableSeparator(line string) bool {
+	return strings.Trim(line, "+|-: ") == ""
+}
+
+func splitTableRow(line string) []string {
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	cells := strings.Split(line, "|")
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(cell)
+	}
+	return cells
+}
+
+type tableColumnType uint8
+
+const (
+	tableInt tableColumnType = iota
+	tableFloat
+	tableBool
+	tableTime
+	tableString
+)
+
+// layouts of dates in tables, the one the MySQL client prints first
+var tableTimeLayouts = []string{"2006-01-02 15:04:05.999999999", time.RFC3339Nano, "2006-01-02"}
+
+func isTableNull(cell string) bool {
+	return strings.EqualFold(cell, "NULL")
+}
+
+func isQuoted(cell string) bool {
+	return len(cell) >= 2 && (cell[0] == '\'' || cell[0] == '"') && cell[len(cell)-1] == cell[0]
+}
+
+// inferTableColumn picks the first type all values of the column fit,
+// bool and time do not contain each other, so a mix of them is a string.
+func inferTableColumn(cells [][]string, column int) tableColumnType {
+	for typ := tableInt; typ < tableString; typ++ {
+		if typ.fitsAll(cells, column) {
+			return typ
+		}
+	}
+	return tableString
+}
+
+func (t tableColumnType) fitsAll(cells [][]string, column int) bool {
+	for _, row := range cells {
+		if cell := row[column]; !isTableNull(cell) && !t.fits(cell) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t tableColumnType) fits(cell string) bool {
+	switch t {
+	case tableInt:
+		_, err := strconv.ParseInt(cell, 10, 64)
+		return err == nil
+	case tableFloat:
+		_, err := strconv.ParseFloat(cell, 64)
+		return err == nil
+	case tableBool:
+		return strings.EqualFold(cell, "true") || strings.EqualFold(cell, "false")
+	case tableTime:
+		_, ok := parseTableTime(cell)
+		return ok
+	}
+	return true
+}
+
+func (t tableColumnType) parse(cell string) driver.Value {
+	if isTableNull(cell) {
+		return nil
+	}
+	switch t {
+	case tableInt:
+		v, _ := strconv.ParseInt(cell, 10, 64)
+		return v
+	case tableFloat:
+		v, _ := strconv.ParseFloat(cell, 64)
+		return v
+	case tableBool:
+		return strings.EqualFold(cell, "true")
+	case tableTime:
+		v, _ := parseTableTime(cell)
+		return v
+	}
+	if isQuoted(cell) {
+		return cell[1 : len(cell)-1]
+	}
+	return cell
+}
+
+func parseTableTime(cell string) (time.Time, bool) {
+	for _, layout := range tableTimeLayouts {
+		if t, err := time.Parse(layout, cell); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// tableString renders the rows as a table like the MySQL client does,
+// every line indented by indent.
+func (r *Rows) tableString(indent string) string {
+	cells := make([][]string, len(r.rows))
+	widths := make([]int, len(r.cols))
+	numeric := make([]bool, len(r.cols))
+	for i, col := range r.cols {
+		widths[i] = utf8.RuneCountInString(col)
+		numeric[i] = true
+	}
+	for n, row := range r.rows {
+		cells[n] = make([]string, len(row))
+		for i, v := range row {
+			cells[n][i] = formatTableCell(v)
+			if w := utf8.RuneCountInString(cells[n][i]); w > widths[i] {
+				widths[i] = w
+			}
+			if _, _, _, ok := toNumber(v); !ok && v != nil {
+				numeric[i] = false
+			}
+		}
+	}
+
+	var sb strings.Builder
+	border := func() {
+		sb.WriteString(indent + "+")
+		for _, w := range widths {
+			sb.WriteString(strings.Repeat("-", w+2) + "+")
+		}
+		sb.WriteByte('\n')
+	}
+	line := func(values []string, alignRight []bool) {
+		sb.WriteString(indent + "|")
+		for i, v := range values {
+			pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(v))
+			if alignRight != nil && alignRight[i] {
+				sb.WriteString(" " + pad + v + " |")
+			} else {
+				sb.WriteString(" " + v + pad + " |")
+			}
+		}
+		sb.WriteByte('\n')
+	}
+
+	border()
+	line(r.cols, nil)
+	border()
+	for _, row := range cells {
+		line(row, numeric)
+	}
+	border()
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func formatTableCell(v driver.Value) string {
+	switch value := v.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		return string(value)
+	case time.Time:
+		return value.Format("2006-01-02 15:04:05.999999999")
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,135 @@
+package sqlmock
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRowsFromMySQLTable(t *testing.T) {
+	rows := NewRows(nil).FromTable(`
+		+----+-------------+--------+------+---------------------+------+
+		| id | name        | rating | open | created             | zip  |
+		+----+-------------+--------+------+---------------------+------+
+		|  1 | Grand Hotel |    4.5 | true | 2023-01-02 03:04:05 | '01' |
+		|  2 | Luxury Inn  |      4 | NULL | 2022-12-31 23:59:59 | 10   |
+		+----+-------------+--------+------+---------------------+------+
+		2 rows in set (0.00 sec)
+	`)
+
+	if expected := []string{"id", "name", "rating", "open", "created", "zip"}; !reflect.DeepEqual(rows.cols, expected) {
+		t.Fatalf("expected columns %v, but got %v", expected, rows.cols)
+	}
+	expected := [][]interface{}{
+		{int64(1), "Grand Hotel", 4.5, true, time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), "01"},
+		{int64(2), "Luxury Inn", float64(4), nil, time.Date(2022, 12, 31, 23, 59, 59, 0, time.UTC), "10"},
+	}
+	for i, row := range expected {
+		for j, v := range row {
+			if !reflect.DeepEqual(rows.rows[i][j], v) && !(v == nil && rows.rows[i][j] == nil) {
+				t.Errorf("expected %#v at row %d, column %d, but got %#v", v, i, j, rows.rows[i][j])
+			}
+		}
+	}
+}
+
+func TestRowsFromMarkdownTable(t *testing.T) {
+	rows := NewRows([]string{"id", "name"}).FromTable("| id | name |\n|---:|:-----|\n| 1 | Grand Hotel |\n| 2 | null |")
+	if len(rows.rows) != 2 || rows.rows[0][0] != int64(1) || rows.rows[0][1] != "Grand Hotel" || rows.rows[1][1] != nil {
+		t.Fatalf("unexpected rows: %v", rows.rows)
+	}
+}
+
+func TestRowsFromTableMixedColumns(t *testing.T) {
+	rows := NewRows(nil).FromTable(`
+		| int_bool | int_date   | float_bool | int_float |
+		| 1        | 1          | 1.5        | 1         |
+		| true     | true       | true       | 2.5       |
+		| NULL     | 2023-01-02 | false      | NULL      |
+	`)
+
+	expected := [][]interface{}{
+		{"1", "1", "1.5", float64(1)},
+		{"true", "true", "true", 2.5},
+		{nil, "2023-01-02", "false", nil},
+	}
+	for i, row := range expected {
+		for j, v := range row {
+			if !reflect.DeepEqual(rows.rows[i][j], v) && !(v == nil && rows.rows[i][j] == nil) {
+				t.Errorf("expected %#v at row %d, column %d, but got %#v", v, i, j, rows.rows[i][j])
+			}
+		}
+	}
+}
+
+func TestRowsFromTableConverter(t *testing.T) {
+	_, mock, err := New(ValueConverterOption(CustomConverter{}))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	rows := mock.NewRows([]string{"name"}).FromTable("| name |\n| john |")
+	if len(rows.rows) != 1 || rows.rows[0][0] != "john" {
+		t.Fatalf("unexpected rows: %v", rows.rows)
+	}
+
+	defer func() {
+		expected := `table row #1, column #0 ("id"): cannot convert int64 with value 1`
+		if err := recover(); err == nil || err.(error).Error() != expected {
+			t.Errorf("expected panic %q, but got %v", expected, err)
+		}
+	}()
+	mock.NewRows([]string{"id"}).FromTable("| id |\n| 1 |")
+}
+
+func TestRowsFromTablePanics(t *testing.T) {
+	cases := map[string]string{
+		"| id | name |\n| 1 |":      "table line 2 has 1 values, but there are 2 columns",
+		"no table at all":           "table has no header",
+		"| id | title |\n| 1 | a |": "table header [id title] does not match columns [id name]",
+	}
+	for table, expected := range cases {
+		func() {
+			defer func() {
+				if err := recover(); err == nil || err.(error).Error() != expected {
+					t.Errorf("expected panic %q, but got %v", expected, err)
+				}
+			}()
+			NewRows([]string{"id", "name"}).FromTable(table)
+		}()
+	}
+}
+
+func TestRowsFromTableString(t *testing.T) {
+	rows := NewRows(nil).FromTable("| id | name |\n| 1 | Grand Hotel |\n| 10 | NULL |")
+	set := &rowSets{sets: []*Rows{rows}}
+	expected := `should return rows:
+    +----+-------------+
+    | id | name        |
+    +----+-------------+
+    |  1 | Grand Hotel |
+    | 10 | NULL        |
+    +----+-------------+`
+	if set.String() != expected {
+		t.Fatalf("expected rows to be printed as a table:\n%s\nbut got:\n%s", expected, set)
+	}
+
+	set = &rowSets{sets: []*Rows{rows, NewRows(nil).FromTable("| total |\n| 2 |")}}
+	expected = `should return rows:
+    result set: 0
+      +----+-------------+
+      | id | name        |
+      +----+-------------+
+      |  1 | Grand Hotel |
+      | 10 | NULL        |
+      +----+-------------+
+    result set: 1
+      +-------+
+      | total |
+      +-------+
+      |     2 |
+      +-------+`
+	if set.String() != expected {
+		t.Fatalf("expected result sets to be printed as tables:\n%s\nbut got:\n%s", expected, set)
+	}
+}
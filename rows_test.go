@@ -3,7 +3,9 @@ package sqlmock
 import (
 	"bytes"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"io"
 	"testing"
 )
 
@@ -497,6 +499,174 @@ func TestEmptyRowSets(t *testing.T) {
 	}
 }
 
+func TestRowsFromFunc(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rows := NewRowsFromFunc([]string{"id", "title"}, func(row int) ([]driver.Value, error) {
+		if row == 3 {
+			return nil, io.EOF
+		}
+		return []driver.Value{row + 1, fmt.Sprintf("title %d", row+1)}, nil
+	})
+	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+
+	rs, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer rs.Close()
+
+	var count int
+	for rs.Next() {
+		var id int
+		var title string
+		if err := rs.Scan(&id, &title); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		count++
+		if id != count || title != fmt.Sprintf("title %d", count) {
+			t.Fatalf("unexpected row values id: %v title: %v", id, title)
+		}
+	}
+	if rs.Err() != nil {
+		t.Fatalf("unexpected error: %s", rs.Err())
+	}
+	if count != 3 {
+		t.Fatalf("expected 3 rows, but got %d", count)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRowsFromFuncErrors(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rows := NewRowsFromFunc([]string{"id"}, func(row int) ([]driver.Value, error) {
+		if row == 1 {
+			return nil, fmt.Errorf("generator error")
+		}
+		return []driver.Value{row}, nil
+	})
+	mock.ExpectQuery("SELECT 1").WillReturnRows(rows)
+	mock.ExpectQuery("SELECT 2").WillReturnRows(NewRowsFromFunc([]string{"id"}, func(row int) ([]driver.Value, error) {
+		return []driver.Value{row, row}, nil
+	}))
+
+	rs, err := db.Query("SELECT 1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !rs.Next() {
+		t.Fatal("expected the first row to be available")
+	}
+	if rs.Next() {
+		t.Fatal("was not expecting the second row, since there should be an error")
+	}
+	if rs.Err() == nil || rs.Err().Error() != "generator error" {
+		t.Fatalf("expected generator error, but got: %v", rs.Err())
+	}
+	rs.Close()
+
+	rs, err = db.Query("SELECT 2")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if rs.Next() {
+		t.Fatal("was not expecting a row, since it has too many values")
+	}
+	if rs.Err() == nil {
+		t.Fatal("expected an error, but got none")
+	}
+	rs.Close()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueryRowBytesInvalidatedByNext_rowsFromFunc(t *testing.T) {
+	t.Parallel()
+	replace := []byte(invalid)
+	rows := NewRowsFromFunc([]string{"raw"}, func(row int) ([]driver.Value, error) {
+		if row == 2 {
+			return nil, io.EOF
+		}
+		return []driver.Value{[]byte(fmt.Sprintf("row %d", row))}, nil
+	})
+	scan := func(rs *sql.Rows) ([]byte, error) {
+		var raw sql.RawBytes
+		return raw, rs.Scan(&raw)
+	}
+	want := []struct {
+		Initial  []byte
+		Replaced []byte
+	}{
+		{Initial: []byte(`row 0`), Replaced: replace[:5]},
+		{Initial: []byte(`row 1`), Replaced: replace[:5]},
+	}
+	queryRowBytesInvalidatedByNext(t, rows, scan, want)
+}
+
+func TestEmptyRowSetsFromFunc(t *testing.T) {
+	set := &rowSets{sets: []*Rows{NewRowsFromFunc([]string{"a"}, func(int) ([]driver.Value, error) {
+		return nil, io.EOF
+	})}}
+
+	if set.empty() {
+		t.Fatalf("expected generated rowset not to be empty, but it was")
+	}
+	if exp := "should return rows:\n    rows generated on demand"; set.String() != exp {
+		t.Fatalf("expected rowset to be described as %q, but got %q", exp, set.String())
+	}
+}
+
+func BenchmarkRowsFromFunc(b *testing.B) {
+	db, mock, err := New()
+	if err != nil {
+		b.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rows := NewRowsFromFunc([]string{"id", "name"}, func(row int) ([]driver.Value, error) {
+		if row == b.N {
+			return nil, io.EOF
+		}
+		return []driver.Value{int64(row), "name"}, nil
+	})
+	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	rs, err := db.Query("SELECT")
+	if err != nil {
+		b.Fatalf("unexpected error: %s", err)
+	}
+	defer rs.Close()
+
+	var id int64
+	var name string
+	for rs.Next() {
+		if err := rs.Scan(&id, &name); err != nil {
+			b.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if rs.Err() != nil {
+		b.Fatalf("unexpected error: %s", rs.Err())
+	}
+}
+
 func queryRowBytesInvalidatedByNext(t *testing.T, rows *Rows, scan func(*sql.Rows) ([]byte, error), want []struct {
 	Initial  []byte
 	Replaced []byte
@@ -0,0 +1,174 @@
+/*
+Package sqlerrors builds the errors MySQL and PostgreSQL report for
+conflicts and failures, which code handling retries or conflicts
+inspects. For MySQL they are the *mysql.MySQLError values of
+go-sql-driver/mysql, for PostgreSQL they are *PostgresError values,
+which have the fields and the SQLState method of the errors of the
+Postgres drivers.
+
+	mock.ExpectExec("INSERT INTO users").
+		WillReturnError(sqlerrors.ErrDuplicateKey(sqlerrors.MySQL, "john@example.com", "users.email"))
+
+The constructors panic if the dialect is unknown, like the mock panics
+when it is set up wrongly. Lookup reports it instead.
+*/
+package sqlerrors
+
+import (
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Dialect names the database whose errors are built.
+type Dialect string
+
+// Dialects errors are built for.
+const (
+	MySQL    Dialect = "mysql"
+	Postgres Dialect = "postgres"
+)
+
+// PostgresError is an error PostgreSQL reports, with the
+// fields pq.Error and pgconn.PgError have in common.
+type PostgresError struct {
+	Severity       string
+	Code           string // the SQLSTATE code, like 23505
+	Message        string
+	Detail         string
+	Hint           string
+	TableName      string
+	ConstraintName string
+}
+
+func (e *PostgresError) Error() string {
+	return fmt.Sprintf("%s: %s (SQLSTATE %s)", e.Severity, e.Message, e.Code)
+}
+
+// SQLState returns the SQLSTATE code of the error.
+func (e *PostgresError) SQLState() string {
+	return e.Code
+}
+
+// dialectError is how a kind of error is reported by each dialect.
+type dialectError struct {
+	mysqlNumber  uint16
+	mysqlMessage string
+	pgCode       string
+	pgMessage    string
+}
+
+func (d dialectError) build(dialect Dialect) error {
+	switch dialect {
+	case MySQL:
+		return &mysql.MySQLError{Number: d.mysqlNumber, Message: d.mysqlMessage}
+	case Postgres:
+		return &PostgresError{Severity: "ERROR", Code: d.pgCode, Message: d.pgMessage}
+	}
+	panic(fmt.Errorf("unknown dialect %q", dialect))
+}
+
+var (
+	deadlock = dialectError{
+		mysqlNumber: 1213, mysqlMessage: "Deadlock found when trying to get lock; try restarting transaction",
+		pgCode: "40P01", pgMessage: "deadlock detected",
+	}
+	lockWaitTimeout = dialectError{
+		mysqlNumber: 1205, mysqlMessage: "Lock wait timeout exceeded; try restarting transaction",
+		pgCode: "55P03", pgMessage: "canceling statement due to lock timeout",
+	}
+	foreignKey = dialectError{
+		mysqlNumber: 1452, mysqlMessage: "Cannot add or update a child row: a foreign key constraint fails",
+		pgCode: "23503", pgMessage: "insert or update on table violates foreign key constraint",
+	}
+	tooManyConnections = dialectError{
+		mysqlNumber: 1040, mysqlMessage: "Too many connections",
+		pgCode: "53300", pgMessage: "sorry, too many clients already",
+	}
+	readOnly = dialectError{
+		mysqlNumber: 1290, mysqlMessage: "The MySQL server is running with the --read-only option so it cannot execute this statement",
+		pgCode: "25006", pgMessage: "cannot execute statement in a read-only transaction",
+	}
+)
+
+// ErrDuplicateKey is reported when a row violates a primary key
+// or unique constraint, MySQL error 1062 or SQLSTATE 23505. The key
+// is the name of the index or constraint, like PRIMARY or users_email_key.
+// The entry is the duplicate value for MySQL, like john@example.com, and
+// the key of the detail for Postgres, like (email)=(john@example.com).
+func ErrDuplicateKey(dialect Dialect, entry, key string) error {
+	switch dialect {
+	case MySQL:
+		return &mysql.MySQLError{Number: 1062, Message: fmt.Sprintf("Duplicate entry '%s' for key '%s'", entry, key)}
+	case Postgres:
+		return &PostgresError{
+			Severity:       "ERROR",
+			Code:           "23505",
+			Message:        fmt.Sprintf("duplicate key value violates unique constraint \"%s\"", key),
+			Detail:         fmt.Sprintf("Key %s already exists.", entry),
+			ConstraintName: key,
+		}
+	}
+	panic(fmt.Errorf("unknown dialect %q", dialect))
+}
+
+// ErrDeadlock is reported when a transaction is chosen as the victim
+// of a deadlock, MySQL error 1213 or SQLSTATE 40P01.
+func ErrDeadlock(dialect Dialect) error {
+	return deadlock.build(dialect)
+}
+
+// ErrLockWaitTimeout is reported when a lock could not be acquired
+// in time, MySQL error 1205 or SQLSTATE 55P03.
+func ErrLockWaitTimeout(dialect Dialect) error {
+	return lockWaitTimeout.build(dialect)
+}
+
+// ErrForeignKey is reported when a row violates a foreign key
+// constraint, MySQL error 1452 or SQLSTATE 23503.
+func ErrForeignKey(dialect Dialect) error {
+	return foreignKey.build(dialect)
+}
+
+// ErrTooManyConnections is reported when the server accepts no more
+// connections, MySQL error 1040 or SQLSTATE 53300.
+func ErrTooManyConnections(dialect Dialect) error {
+	return tooManyConnections.build(dialect)
+}
+
+// ErrReadOnly is reported when a write is executed on a read only
+// server or transaction, MySQL error 1290 or SQLSTATE 25006.
+func ErrReadOnly(dialect Dialect) error {
+	return readOnly.build(dialect)
+}
+
+// errorsByName are the errors by the names Lookup knows them by.
+var errorsByName = map[string]dialectError{
+	"deadlock":           deadlock,
+	"lockWaitTimeout":    lockWaitTimeout,
+	"foreignKey":         foreignKey,
+	"tooManyConnections": tooManyConnections,
+	"readOnly":           readOnly,
+}
+
+// Lookup builds an error of the dialect by its name, which is the name
+// of its constructor without Err, like "duplicateKey" or "deadlock",
+// with the arguments the constructor takes besides the dialect.
+// It is how JSON fixtures refer to errors. It returns false if the
+// dialect or the name is unknown, or the arguments do not fit.
+func Lookup(dialect Dialect, name string, args ...string) (e error, ok bool) {
+	if dialect != MySQL && dialect != Postgres {
+		return nil, false
+	}
+	if name == "duplicateKey" {
+		if len(args) != 2 {
+			return nil, false
+		}
+		return ErrDuplicateKey(dialect, args[0], args[1]), true
+	}
+	d, ok := errorsByName[name]
+	if !ok || len(args) != 0 {
+		return nil, false
+	}
+	return d.build(dialect), true
+}
@@ -0,0 +1,96 @@
+package sqlerrors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestMySQLErrors(t *testing.T) {
+	for number, err := range map[uint16]error{
+		1062: ErrDuplicateKey(MySQL, "john", "PRIMARY"),
+		1213: ErrDeadlock(MySQL),
+		1205: ErrLockWaitTimeout(MySQL),
+		1452: ErrForeignKey(MySQL),
+		1040: ErrTooManyConnections(MySQL),
+		1290: ErrReadOnly(MySQL),
+	} {
+		var mysqlErr *mysql.MySQLError
+		if !errors.As(err, &mysqlErr) {
+			t.Fatalf("expected a *mysql.MySQLError, but got %T", err)
+		}
+		if mysqlErr.Number != number {
+			t.Errorf("expected error number %d, but got %d", number, mysqlErr.Number)
+		}
+	}
+}
+
+func TestPostgresErrors(t *testing.T) {
+	for code, err := range map[string]error{
+		"23505": ErrDuplicateKey(Postgres, "(id)=(1)", "users_pkey"),
+		"40P01": ErrDeadlock(Postgres),
+		"55P03": ErrLockWaitTimeout(Postgres),
+		"23503": ErrForeignKey(Postgres),
+		"53300": ErrTooManyConnections(Postgres),
+		"25006": ErrReadOnly(Postgres),
+	} {
+		var pgErr interface{ SQLState() string }
+		if !errors.As(err, &pgErr) {
+			t.Fatalf("expected an error with SQLState, but got %T", err)
+		}
+		if pgErr.SQLState() != code {
+			t.Errorf("expected SQLSTATE %s, but got %s", code, pgErr.SQLState())
+		}
+	}
+
+	expected := `ERROR: duplicate key value violates unique constraint "users_email_key" (SQLSTATE 23505)`
+	err := ErrDuplicateKey(Postgres, "(email)=(john@example.com)", "users_email_key")
+	if err.Error() != expected {
+		t.Errorf("expected %q, but got %q", expected, err)
+	}
+	pgErr := err.(*PostgresError)
+	if pgErr.Detail != "Key (email)=(john@example.com) already exists." || pgErr.ConstraintName != "users_email_key" {
+		t.Errorf("unexpected detail %q or constraint %q", pgErr.Detail, pgErr.ConstraintName)
+	}
+}
+
+func TestMySQLDuplicateKeyMessage(t *testing.T) {
+	expected := "Error 1062: Duplicate entry 'john@example.com' for key 'users.email'"
+	if err := ErrDuplicateKey(MySQL, "john@example.com", "users.email"); err.Error() != expected {
+		t.Errorf("expected %q, but got %q", expected, err)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	err, ok := Lookup(MySQL, "deadlock")
+	if !ok || err.Error() != ErrDeadlock(MySQL).Error() {
+		t.Errorf("expected the deadlock error, but got %v", err)
+	}
+	if _, ok := Lookup(MySQL, "outOfMemory"); ok {
+		t.Error("expected an unknown error not to be found")
+	}
+	if _, ok := Lookup("oracle", "deadlock"); ok {
+		t.Error("expected an unknown dialect not to be found")
+	}
+
+	err, ok = Lookup(MySQL, "duplicateKey", "john", "PRIMARY")
+	if !ok || err.Error() != ErrDuplicateKey(MySQL, "john", "PRIMARY").Error() {
+		t.Errorf("expected the duplicate key error, but got %v", err)
+	}
+	if _, ok := Lookup(MySQL, "duplicateKey"); ok {
+		t.Error("expected the duplicate key error without its entry and key not to be found")
+	}
+	if _, ok := Lookup(MySQL, "deadlock", "extra"); ok {
+		t.Error("expected an error with unexpected arguments not to be found")
+	}
+}
+
+func TestUnknownDialect(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an unknown dialect")
+		}
+	}()
+	ErrDeadlock("oracle")
+}
@@ -45,6 +45,16 @@ type SqlmockCommon interface {
 	// the *ExpectedExec allows to mock database response
 	ExpectExec(expectedSQL string) *ExpectedExec
 
+	// ExpectQueryIn expects Query() or QueryRow() to be called with
+	// the query and arguments sqlx.In expands expectedSQL and args to,
+	// no matter which bind type the query is rebound to.
+	ExpectQueryIn(expectedSQL string, args ...interface{}) *ExpectedQuery
+
+	// ExpectExecIn expects Exec() to be called with the query and
+	// arguments sqlx.In expands expectedSQL and args to,
+	// no matter which bind type the query is rebound to.
+	ExpectExecIn(expectedSQL string, args ...interface{}) *ExpectedExec
+
 	// ExpectBegin expects *sql.DB.Begin to be called.
 	// the *ExpectedBegin allows to mock database response
 	ExpectBegin() *ExpectedBegin
@@ -85,6 +95,15 @@ type SqlmockCommon interface {
 	// sql driver.Value slice or from the CSV string and
 	// to be used as sql driver.Rows.
 	NewRows(columns []string) *Rows
+
+	// NewRowsFromFunc allows Rows to be created from a
+	// RowsFunc, which generates every row on demand and
+	// to be used as sql driver.Rows.
+	NewRowsFromFunc(columns []string, fn RowsFunc) *Rows
+
+	// ResetAutoIncrement starts the sequences of insert ids
+	// generated with AutoIncrementOption over, for every table.
+	ResetAutoIncrement()
 }
 
 type sqlmock struct {
@@ -96,7 +115,18 @@ type sqlmock struct {
 	queryMatcher QueryMatcher
 	monitorPings bool
 
+	argsComparison ArgsComparison
+	resources      resources
+	protocol       protocol
+	rowsMustBeRead bool
+	driverProfile  *DriverProfile
+	dialect        DialectProfile
+	autoIncrement  *autoIncrement
+
+	countPlaceholders bool
+
 	expected []expectation
+	index    expectationIndex
 }
 
 func (c *sqlmock) open(options []func(*sqlmock) error) (*sql.DB, Sqlmock, error) {
@@ -159,7 +189,7 @@ func (c *sqlmock) openx(options []func(*sqlmock) error) (*sqlx.DB, Sqlmock, erro
 
 func (c *sqlmock) ExpectClose() *ExpectedClose {
 	e := &ExpectedClose{}
-	c.expected = append(c.expected, e)
+	c.add(e)
 	return e
 }
 
@@ -181,9 +211,9 @@ func (c *sqlmock) Close() error {
 	}
 
 	var expected *ExpectedClose
-	var fulfilled int
 	var ok bool
-	for _, next := range c.expected {
+	fulfilled, pending := c.pending()
+	for _, next := range pending {
 		next.Lock()
 		if next.fulfilled() {
 			next.Unlock()
@@ -229,6 +259,9 @@ func (c *sqlmock) ExpectationsWereMet() error {
 			if prep.mustBeClosed && !prep.wasClosed {
 				return fmt.Errorf("expected prepared statement to be closed, but it was not: %s", prep)
 			}
+			if prep.mustExecute > 0 && prep.executions != prep.mustExecute {
+				return fmt.Errorf("expected prepared statement to be executed %d times, but it was executed %d times: %s", prep.mustExecute, prep.executions, prep)
+			}
 		}
 
 		// must check whether all expected queried rows are closed
@@ -236,9 +269,20 @@ func (c *sqlmock) ExpectationsWereMet() error {
 			if query.rowsMustBeClosed && !query.rowsWereClosed {
 				return fmt.Errorf("expected query rows to be closed, but it was not: %s", query)
 			}
+			if (query.rowsMustBeRead || c.rowsMustBeRead) && query.err == nil && query.rows != nil {
+				if !query.rowsWereClosed {
+					return fmt.Errorf("expected query rows to be fully read, but they were not closed: %s", query)
+				}
+				if query.rowsUnread != "" {
+					return fmt.Errorf("expected query rows to be fully read, but %s: %s", query.rowsUnread, query)
+				}
+			}
 		}
 	}
-	return nil
+	if err := c.protocolViolations(); err != nil {
+		return err
+	}
+	return c.leakedResources()
 }
 
 // Begin meets http://golang.org/pkg/database/sql/driver/#Conn interface
@@ -251,14 +295,15 @@ func (c *sqlmock) Begin() (driver.Tx, error) {
 		return nil, err
 	}
 
+	c.beginTransaction()
 	return c, nil
 }
 
 func (c *sqlmock) begin() (*ExpectedBegin, error) {
 	var expected *ExpectedBegin
 	var ok bool
-	var fulfilled int
-	for _, next := range c.expected {
+	fulfilled, pending := c.pending()
+	for _, next := range pending {
 		next.Lock()
 		if next.fulfilled() {
 			next.Unlock()
@@ -291,7 +336,7 @@ func (c *sqlmock) begin() (*ExpectedBegin, error) {
 
 func (c *sqlmock) ExpectBegin() *ExpectedBegin {
 	e := &ExpectedBegin{}
-	c.expected = append(c.expected, e)
+	c.add(e)
 	return e
 }
 
@@ -299,7 +344,14 @@ func (c *sqlmock) ExpectExec(expectedSQL string) *ExpectedExec {
 	e := &ExpectedExec{}
 	e.expectSQL = expectedSQL
 	e.converter = c.converter
-	c.expected = append(c.expected, e)
+	e.mock = c
+	c.add(e)
+	return e
+}
+
+func (c *sqlmock) ExpectExecIn(expectedSQL string, args ...interface{}) *ExpectedExec {
+	e := c.ExpectExec(expectedSQL)
+	e.bindIn(e, args)
 	return e
 }
 
@@ -313,15 +365,21 @@ func (c *sqlmock) Prepare(query string) (driver.Stmt, error) {
 		return nil, err
 	}
 
-	return &statement{c, ex, query}, nil
+	stmt := &statement{conn: c, ex: ex, query: query}
+	c.openStatement(stmt)
+	return stmt, nil
 }
 
 func (c *sqlmock) prepare(query string) (*ExpectedPrepare, error) {
 	var expected *ExpectedPrepare
-	var fulfilled int
 	var ok bool
-
-	for _, next := range c.expected {
+	fulfilled, pending := c.pending()
+	if !c.ordered {
+		if candidates, ok := c.candidates(query); ok {
+			pending = candidates
+		}
+	}
+	for _, next := range pending {
 		next.Lock()
 		if next.fulfilled() {
 			next.Unlock()
@@ -339,7 +397,7 @@ func (c *sqlmock) prepare(query string) (*ExpectedPrepare, error) {
 		}
 
 		if pr, ok := next.(*ExpectedPrepare); ok {
-			if err := c.queryMatcher.Match(pr.expectSQL, query); err == nil {
+			if err := pr.sqlMatcher.match(c.queryMatcher, pr.expectSQL, query); err == nil {
 				expected = pr
 				break
 			}
@@ -355,7 +413,7 @@ func (c *sqlmock) prepare(query string) (*ExpectedPrepare, error) {
 		return nil, fmt.Errorf(msg, query)
 	}
 	defer expected.Unlock()
-	if err := c.queryMatcher.Match(expected.expectSQL, query); err != nil {
+	if err := expected.sqlMatcher.match(c.queryMatcher, expected.expectSQL, query); err != nil {
 		return nil, fmt.Errorf("Prepare: %v", err)
 	}
 
@@ -365,7 +423,7 @@ func (c *sqlmock) prepare(query string) (*ExpectedPrepare, error) {
 
 func (c *sqlmock) ExpectPrepare(expectedSQL string) *ExpectedPrepare {
 	e := &ExpectedPrepare{expectSQL: expectedSQL, mock: c}
-	c.expected = append(c.expected, e)
+	c.add(e)
 	return e
 }
 
@@ -373,28 +431,36 @@ func (c *sqlmock) ExpectQuery(expectedSQL string) *ExpectedQuery {
 	e := &ExpectedQuery{}
 	e.expectSQL = expectedSQL
 	e.converter = c.converter
-	c.expected = append(c.expected, e)
+	e.mock = c
+	c.add(e)
+	return e
+}
+
+func (c *sqlmock) ExpectQueryIn(expectedSQL string, args ...interface{}) *ExpectedQuery {
+	e := c.ExpectQuery(expectedSQL)
+	e.bindIn(e, args)
 	return e
 }
 
 func (c *sqlmock) ExpectCommit() *ExpectedCommit {
 	e := &ExpectedCommit{}
-	c.expected = append(c.expected, e)
+	c.add(e)
 	return e
 }
 
 func (c *sqlmock) ExpectRollback() *ExpectedRollback {
 	e := &ExpectedRollback{}
-	c.expected = append(c.expected, e)
+	c.add(e)
 	return e
 }
 
 // Commit meets http://golang.org/pkg/database/sql/driver/#Tx
 func (c *sqlmock) Commit() error {
+	c.finishTransaction("committed")
 	var expected *ExpectedCommit
-	var fulfilled int
 	var ok bool
-	for _, next := range c.expected {
+	fulfilled, pending := c.pending()
+	for _, next := range pending {
 		next.Lock()
 		if next.fulfilled() {
 			next.Unlock()
@@ -426,10 +492,11 @@ func (c *sqlmock) Commit() error {
 
 // Rollback meets http://golang.org/pkg/database/sql/driver/#Tx
 func (c *sqlmock) Rollback() error {
+	c.finishTransaction("rolled back")
 	var expected *ExpectedRollback
-	var fulfilled int
 	var ok bool
-	for _, next := range c.expected {
+	fulfilled, pending := c.pending()
+	for _, next := range pending {
 		next.Lock()
 		if next.fulfilled() {
 			next.Unlock()
@@ -467,3 +534,12 @@ func (c *sqlmock) NewRows(columns []string) *Rows {
 	r.converter = c.converter
 	return r
 }
+
+// NewRowsFromFunc allows Rows to be created from a
+// RowsFunc, which generates every row on demand and
+// to be used as sql driver.Rows.
+func (c *sqlmock) NewRowsFromFunc(columns []string, fn RowsFunc) *Rows {
+	r := NewRowsFromFunc(columns, fn)
+	r.converter = c.converter
+	return r
+}
@@ -44,14 +44,20 @@ func (c *sqlmock) Query(query string, args []driver.Value) (driver.Rows, error)
 		return nil, err
 	}
 
+	c.openRows(ex, query)
 	return ex.rows, nil
 }
 
 func (c *sqlmock) query(query string, args []namedValue) (*ExpectedQuery, error) {
 	var expected *ExpectedQuery
-	var fulfilled int
 	var ok bool
-	for _, next := range c.expected {
+	fulfilled, pending := c.pending()
+	if !c.ordered {
+		if candidates, ok := c.candidates(query); ok {
+			pending = candidates
+		}
+	}
+	for _, next := range pending {
 		next.Lock()
 		if next.fulfilled() {
 			next.Unlock()
@@ -67,7 +73,11 @@ func (c *sqlmock) query(query string, args []namedValue) (*ExpectedQuery, error)
 			return nil, fmt.Errorf("call to Query '%s' with args %+v, was not expected, next expectation is: %s", query, args, next)
 		}
 		if qr, ok := next.(*ExpectedQuery); ok {
-			if err := c.queryMatcher.Match(qr.expectSQL, query); err != nil {
+			if err := qr.sqlMatcher.match(c.queryMatcher, qr.expectSQL, query); err != nil {
+				next.Unlock()
+				continue
+			}
+			if err := qr.sqlMatcher.matchArgs(query, len(args)); err != nil {
 				next.Unlock()
 				continue
 			}
@@ -89,19 +99,29 @@ func (c *sqlmock) query(query string, args []namedValue) (*ExpectedQuery, error)
 
 	defer expected.Unlock()
 
-	if err := c.queryMatcher.Match(expected.expectSQL, query); err != nil {
+	if err := expected.sqlMatcher.match(c.queryMatcher, expected.expectSQL, query); err != nil {
 		return nil, fmt.Errorf("Query: %v", err)
 	}
 
+	if err := expected.sqlMatcher.matchArgs(query, len(args)); err != nil {
+		return nil, fmt.Errorf("Query '%s', %v", query, err)
+	}
+
 	if err := expected.argsMatches(args); err != nil {
 		return nil, fmt.Errorf("Query '%s', arguments do not match: %s", query, err)
 	}
 
+	expected.captureArgs(args)
 	expected.triggered = true
 	if expected.err != nil {
 		return expected, expected.err // mocked to return error
 	}
 
+	if expected.rows == nil && c.autoIncrement != nil {
+		if rows, ok := c.autoIncrement.returning(query); ok {
+			expected.WillReturnRows(rows)
+		}
+	}
 	if expected.rows == nil {
 		return nil, fmt.Errorf("Query '%s' with args %+v, must return a database/sql/driver.Rows, but it was not set for expectation %T as %+v", query, args, expected, expected)
 	}
@@ -118,7 +138,7 @@ func (c *sqlmock) Exec(query string, args []driver.Value) (driver.Result, error)
 		}
 	}
 
-	ex, err := c.exec(query, namedArgs)
+	ex, res, err := c.exec(query, namedArgs)
 	if ex != nil {
 		time.Sleep(ex.delay)
 	}
@@ -126,14 +146,19 @@ func (c *sqlmock) Exec(query string, args []driver.Value) (driver.Result, error)
 		return nil, err
 	}
 
-	return ex.result, nil
+	return res, nil
 }
 
-func (c *sqlmock) exec(query string, args []namedValue) (*ExpectedExec, error) {
+func (c *sqlmock) exec(query string, args []namedValue) (*ExpectedExec, driver.Result, error) {
 	var expected *ExpectedExec
-	var fulfilled int
 	var ok bool
-	for _, next := range c.expected {
+	fulfilled, pending := c.pending()
+	if !c.ordered {
+		if candidates, ok := c.candidates(query); ok {
+			pending = candidates
+		}
+	}
+	for _, next := range pending {
 		next.Lock()
 		if next.fulfilled() {
 			next.Unlock()
@@ -146,10 +171,14 @@ func (c *sqlmock) exec(query string, args []namedValue) (*ExpectedExec, error) {
 				break
 			}
 			next.Unlock()
-			return nil, fmt.Errorf("call to ExecQuery '%s' with args %+v, was not expected, next expectation is: %s", query, args, next)
+			return nil, nil, fmt.Errorf("call to ExecQuery '%s' with args %+v, was not expected, next expectation is: %s", query, args, next)
 		}
 		if exec, ok := next.(*ExpectedExec); ok {
-			if err := c.queryMatcher.Match(exec.expectSQL, query); err != nil {
+			if err := exec.sqlMatcher.match(c.queryMatcher, exec.expectSQL, query); err != nil {
+				next.Unlock()
+				continue
+			}
+			if err := exec.sqlMatcher.matchArgs(query, len(args)); err != nil {
 				next.Unlock()
 				continue
 			}
@@ -166,26 +195,34 @@ func (c *sqlmock) exec(query string, args []namedValue) (*ExpectedExec, error) {
 		if fulfilled == len(c.expected) {
 			msg = "all expectations were already fulfilled, " + msg
 		}
-		return nil, fmt.Errorf(msg, query, args)
+		return nil, nil, fmt.Errorf(msg, query, args)
 	}
 	defer expected.Unlock()
 
-	if err := c.queryMatcher.Match(expected.expectSQL, query); err != nil {
-		return nil, fmt.Errorf("ExecQuery: %v", err)
+	if err := expected.sqlMatcher.match(c.queryMatcher, expected.expectSQL, query); err != nil {
+		return nil, nil, fmt.Errorf("ExecQuery: %v", err)
+	}
+
+	if err := expected.sqlMatcher.matchArgs(query, len(args)); err != nil {
+		return nil, nil, fmt.Errorf("ExecQuery '%s', %v", query, err)
 	}
 
 	if err := expected.argsMatches(args); err != nil {
-		return nil, fmt.Errorf("ExecQuery '%s', arguments do not match: %s", query, err)
+		return nil, nil, fmt.Errorf("ExecQuery '%s', arguments do not match: %s", query, err)
 	}
 
-	expected.triggered = true
-	if expected.err != nil {
-		return expected, expected.err // mocked to return error
+	expected.captureArgs(args)
+	res, err := expected.call()
+	if err != nil {
+		return expected, nil, err // mocked to return error
+	}
+	if c.autoIncrement != nil {
+		res = c.autoIncrement.result(query, res)
 	}
 
-	if expected.result == nil {
-		return nil, fmt.Errorf("ExecQuery '%s' with args %+v, must return a database/sql/driver.Result, but it was not set for expectation %T as %+v", query, args, expected, expected)
+	if res == nil {
+		return nil, nil, fmt.Errorf("ExecQuery '%s' with args %+v, must return a database/sql/driver.Result, but it was not set for expectation %T as %+v", query, args, expected, expected)
 	}
 
-	return expected, nil
+	return expected, c.driverResult(res), nil
 }
@@ -38,6 +38,7 @@ func (c *sqlmock) QueryContext(ctx context.Context, query string, args []driver.
 			if err != nil {
 				return nil, err
 			}
+			c.openRows(ex, query)
 			return ex.rows, nil
 		case <-ctx.Done():
 			return nil, ErrCancelled
@@ -49,14 +50,14 @@ func (c *sqlmock) QueryContext(ctx context.Context, query string, args []driver.
 
 // Implement the "ExecerContext" interface
 func (c *sqlmock) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
-	ex, err := c.exec(query, args)
+	ex, res, err := c.exec(query, args)
 	if ex != nil {
 		select {
 		case <-time.After(ex.delay):
 			if err != nil {
 				return nil, err
 			}
-			return ex.result, nil
+			return res, nil
 		case <-ctx.Done():
 			return nil, ErrCancelled
 		}
@@ -74,6 +75,7 @@ func (c *sqlmock) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx
 			if err != nil {
 				return nil, err
 			}
+			c.beginTransaction()
 			return c, nil
 		case <-ctx.Done():
 			return nil, ErrCancelled
@@ -92,7 +94,9 @@ func (c *sqlmock) PrepareContext(ctx context.Context, query string) (driver.Stmt
 			if err != nil {
 				return nil, err
 			}
-			return &statement{c, ex, query}, nil
+			stmt := &statement{conn: c, ex: ex, query: query}
+			c.openStatement(stmt)
+			return stmt, nil
 		case <-ctx.Done():
 			return nil, ErrCancelled
 		}
@@ -121,9 +125,9 @@ func (c *sqlmock) Ping(ctx context.Context) error {
 
 func (c *sqlmock) ping() (*ExpectedPing, error) {
 	var expected *ExpectedPing
-	var fulfilled int
 	var ok bool
-	for _, next := range c.expected {
+	fulfilled, pending := c.pending()
+	for _, next := range pending {
 		next.Lock()
 		if next.fulfilled() {
 			next.Unlock()
@@ -156,11 +160,17 @@ func (c *sqlmock) ping() (*ExpectedPing, error) {
 
 // Implement the "StmtExecContext" interface
 func (stmt *statement) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if err := stmt.execute(); err != nil {
+		return nil, err
+	}
 	return stmt.conn.ExecContext(ctx, stmt.query, args)
 }
 
 // Implement the "StmtQueryContext" interface
 func (stmt *statement) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if err := stmt.execute(); err != nil {
+		return nil, err
+	}
 	return stmt.conn.QueryContext(ctx, stmt.query, args)
 }
 
@@ -170,7 +180,7 @@ func (c *sqlmock) ExpectPing() *ExpectedPing {
 		return nil
 	}
 	e := &ExpectedPing{}
-	c.expected = append(c.expected, e)
+	c.add(e)
 	return e
 }
 
@@ -193,14 +203,20 @@ func (c *sqlmock) Query(query string, args []driver.Value) (driver.Rows, error)
 		return nil, err
 	}
 
+	c.openRows(ex, query)
 	return ex.rows, nil
 }
 
 func (c *sqlmock) query(query string, args []driver.NamedValue) (*ExpectedQuery, error) {
 	var expected *ExpectedQuery
-	var fulfilled int
 	var ok bool
-	for _, next := range c.expected {
+	fulfilled, pending := c.pending()
+	if !c.ordered {
+		if candidates, ok := c.candidates(query); ok {
+			pending = candidates
+		}
+	}
+	for _, next := range pending {
 		next.Lock()
 		if next.fulfilled() {
 			next.Unlock()
@@ -216,7 +232,11 @@ func (c *sqlmock) query(query string, args []driver.NamedValue) (*ExpectedQuery,
 			return nil, fmt.Errorf("call to Query '%s' with args %+v, was not expected, next expectation is: %s", query, args, next)
 		}
 		if qr, ok := next.(*ExpectedQuery); ok {
-			if err := c.queryMatcher.Match(qr.expectSQL, query); err != nil {
+			if err := qr.sqlMatcher.match(c.queryMatcher, qr.expectSQL, query); err != nil {
+				next.Unlock()
+				continue
+			}
+			if err := qr.sqlMatcher.matchArgs(query, len(args)); err != nil {
 				next.Unlock()
 				continue
 			}
@@ -238,19 +258,32 @@ func (c *sqlmock) query(query string, args []driver.NamedValue) (*ExpectedQuery,
 
 	defer expected.Unlock()
 
-	if err := c.queryMatcher.Match(expected.expectSQL, query); err != nil {
+	if err := expected.sqlMatcher.match(c.queryMatcher, expected.expectSQL, query); err != nil {
 		return nil, fmt.Errorf("Query: %v", err)
 	}
 
+	if err := expected.sqlMatcher.matchArgs(query, len(args)); err != nil {
+		return nil, fmt.Errorf("Query '%s', %v", query, err)
+	}
+
 	if err := expected.argsMatches(args); err != nil {
 		return nil, fmt.Errorf("Query '%s', arguments do not match: %s", query, err)
 	}
 
+	expected.captureArgs(args)
+	if err := expected.setOutputs(args); err != nil {
+		return nil, fmt.Errorf("Query '%s', %s", query, err)
+	}
 	expected.triggered = true
 	if expected.err != nil {
 		return expected, expected.err // mocked to return error
 	}
 
+	if expected.rows == nil && c.autoIncrement != nil {
+		if rows, ok := c.autoIncrement.returning(query); ok {
+			expected.WillReturnRows(rows)
+		}
+	}
 	if expected.rows == nil {
 		return nil, fmt.Errorf("Query '%s' with args %+v, must return a database/sql/driver.Rows, but it was not set for expectation %T as %+v", query, args, expected, expected)
 	}
@@ -268,7 +301,7 @@ func (c *sqlmock) Exec(query string, args []driver.Value) (driver.Result, error)
 		}
 	}
 
-	ex, err := c.exec(query, namedArgs)
+	ex, res, err := c.exec(query, namedArgs)
 	if ex != nil {
 		time.Sleep(ex.delay)
 	}
@@ -276,14 +309,19 @@ func (c *sqlmock) Exec(query string, args []driver.Value) (driver.Result, error)
 		return nil, err
 	}
 
-	return ex.result, nil
+	return res, nil
 }
 
-func (c *sqlmock) exec(query string, args []driver.NamedValue) (*ExpectedExec, error) {
+func (c *sqlmock) exec(query string, args []driver.NamedValue) (*ExpectedExec, driver.Result, error) {
 	var expected *ExpectedExec
-	var fulfilled int
 	var ok bool
-	for _, next := range c.expected {
+	fulfilled, pending := c.pending()
+	if !c.ordered {
+		if candidates, ok := c.candidates(query); ok {
+			pending = candidates
+		}
+	}
+	for _, next := range pending {
 		next.Lock()
 		if next.fulfilled() {
 			next.Unlock()
@@ -296,10 +334,14 @@ func (c *sqlmock) exec(query string, args []driver.NamedValue) (*ExpectedExec, e
 				break
 			}
 			next.Unlock()
-			return nil, fmt.Errorf("call to ExecQuery '%s' with args %+v, was not expected, next expectation is: %s", query, args, next)
+			return nil, nil, fmt.Errorf("call to ExecQuery '%s' with args %+v, was not expected, next expectation is: %s", query, args, next)
 		}
 		if exec, ok := next.(*ExpectedExec); ok {
-			if err := c.queryMatcher.Match(exec.expectSQL, query); err != nil {
+			if err := exec.sqlMatcher.match(c.queryMatcher, exec.expectSQL, query); err != nil {
+				next.Unlock()
+				continue
+			}
+			if err := exec.sqlMatcher.matchArgs(query, len(args)); err != nil {
 				next.Unlock()
 				continue
 			}
@@ -316,28 +358,39 @@ func (c *sqlmock) exec(query string, args []driver.NamedValue) (*ExpectedExec, e
 		if fulfilled == len(c.expected) {
 			msg = "all expectations were already fulfilled, " + msg
 		}
-		return nil, fmt.Errorf(msg, query, args)
+		return nil, nil, fmt.Errorf(msg, query, args)
 	}
 	defer expected.Unlock()
 
-	if err := c.queryMatcher.Match(expected.expectSQL, query); err != nil {
-		return nil, fmt.Errorf("ExecQuery: %v", err)
+	if err := expected.sqlMatcher.match(c.queryMatcher, expected.expectSQL, query); err != nil {
+		return nil, nil, fmt.Errorf("ExecQuery: %v", err)
+	}
+
+	if err := expected.sqlMatcher.matchArgs(query, len(args)); err != nil {
+		return nil, nil, fmt.Errorf("ExecQuery '%s', %v", query, err)
 	}
 
 	if err := expected.argsMatches(args); err != nil {
-		return nil, fmt.Errorf("ExecQuery '%s', arguments do not match: %s", query, err)
+		return nil, nil, fmt.Errorf("ExecQuery '%s', arguments do not match: %s", query, err)
 	}
 
-	expected.triggered = true
-	if expected.err != nil {
-		return expected, expected.err // mocked to return error
+	expected.captureArgs(args)
+	if err := expected.setOutputs(args); err != nil {
+		return nil, nil, fmt.Errorf("ExecQuery '%s', %s", query, err)
+	}
+	res, err := expected.call()
+	if err != nil {
+		return expected, nil, err // mocked to return error
+	}
+	if c.autoIncrement != nil {
+		res = c.autoIncrement.result(query, res)
 	}
 
-	if expected.result == nil {
-		return nil, fmt.Errorf("ExecQuery '%s' with args %+v, must return a database/sql/driver.Result, but it was not set for expectation %T as %+v", query, args, expected, expected)
+	if res == nil {
+		return nil, nil, fmt.Errorf("ExecQuery '%s' with args %+v, must return a database/sql/driver.Result, but it was not set for expectation %T as %+v", query, args, expected, expected)
 	}
 
-	return expected, nil
+	return expected, c.driverResult(res), nil
 }
 
 // @TODO maybe add ExpectedBegin.WithOptions(driver.TxOptions)
@@ -1,16 +1,49 @@
 package sqlmock
 
+import "fmt"
+
 type statement struct {
-	conn  *sqlmock
-	ex    *ExpectedPrepare
-	query string
+	conn   *sqlmock
+	ex     *ExpectedPrepare
+	query  string
+	closed bool
 }
 
 func (stmt *statement) Close() error {
+	if stmt.closed {
+		return fmt.Errorf("prepared statement '%s' was already closed", stmt.query)
+	}
+	stmt.closed = true
 	stmt.ex.wasClosed = true
 	return stmt.ex.closeErr
 }
 
+// NumInput returns the number of arguments declared by WithNumInput,
+// or the number of placeholders in the prepared query if they are
+// counted, see CountPlaceholdersOption, or else -1, so that database/sql
+// does not verify the number of arguments.
 func (stmt *statement) NumInput() int {
-	return -1
+	if stmt.ex.hasNumInput {
+		return stmt.ex.numInput
+	}
+	if stmt.conn == nil || !stmt.conn.countPlaceholders {
+		return -1
+	}
+	_, n, err := questionPlaceholders(stmt.query)
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// execute counts an execution of the statement,
+// which must not be closed.
+func (stmt *statement) execute() error {
+	if stmt.closed {
+		return fmt.Errorf("prepared statement '%s' is used after it was closed", stmt.query)
+	}
+	stmt.ex.Lock()
+	stmt.ex.executions++
+	stmt.ex.Unlock()
+	return nil
 }
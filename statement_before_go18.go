@@ -8,10 +8,16 @@ import (
 
 // Deprecated: Drivers should implement ExecerContext instead.
 func (stmt *statement) Exec(args []driver.Value) (driver.Result, error) {
+	if err := stmt.execute(); err != nil {
+		return nil, err
+	}
 	return stmt.conn.Exec(stmt.query, args)
 }
 
 // Deprecated: Drivers should implement StmtQueryContext instead (or additionally).
 func (stmt *statement) Query(args []driver.Value) (driver.Rows, error) {
+	if err := stmt.execute(); err != nil {
+		return nil, err
+	}
 	return stmt.conn.Query(stmt.query, args)
 }
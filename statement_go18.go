@@ -9,11 +9,17 @@ import (
 
 // Deprecated: Drivers should implement ExecerContext instead.
 func (stmt *statement) Exec(args []driver.Value) (driver.Result, error) {
+	if err := stmt.execute(); err != nil {
+		return nil, err
+	}
 	return stmt.conn.ExecContext(context.Background(), stmt.query, convertValueToNamedValue(args))
 }
 
 // Deprecated: Drivers should implement StmtQueryContext instead (or additionally).
 func (stmt *statement) Query(args []driver.Value) (driver.Rows, error) {
+	if err := stmt.execute(); err != nil {
+		return nil, err
+	}
 	return stmt.conn.QueryContext(context.Background(), stmt.query, convertValueToNamedValue(args))
 }
 
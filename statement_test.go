@@ -3,7 +3,9 @@
 package sqlmock
 
 import (
+	"database/sql/driver"
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -31,3 +33,133 @@ func TestExpectedPreparedStatementCloseError(t *testing.T) {
 		t.Fatalf("got = %v, want = %v", err, want)
 	}
 }
+
+func TestPreparedStatementNumInput(t *testing.T) {
+	conn, mock, err := New(CountPlaceholdersOption(true))
+	if err != nil {
+		t.Fatal("failed to open sqlmock database:", err)
+	}
+	defer conn.Close()
+
+	mock.ExpectPrepare("INSERT INTO users").ExpectExec().WillReturnResult(NewResult(1, 1))
+
+	stmt, err := conn.Prepare("INSERT INTO users (name, email) VALUES (?, ?)")
+	if err != nil {
+		t.Fatal("unexpected error while preparing a statement:", err)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec("john"); err == nil || !strings.Contains(err.Error(), "expected 2 arguments, got 1") {
+		t.Fatalf("expected an error about the number of arguments, but got: %v", err)
+	}
+	if _, err := stmt.Exec("john", "john@example.com"); err != nil {
+		t.Fatal("unexpected error while executing the statement:", err)
+	}
+}
+
+func TestPreparedStatementWithNumInput(t *testing.T) {
+	for query, want := range map[string]int{
+		"SELECT * FROM users WHERE id = ? AND name = ?": 2,
+		"SELECT * FROM users WHERE id = $1 OR $1 = 0":   1,
+		"SELECT 1":                                      0,
+	} {
+		stmt := &statement{conn: &sqlmock{countPlaceholders: true}, ex: &ExpectedPrepare{}, query: query}
+		if n := stmt.NumInput(); n != want {
+			t.Errorf("%s: expected %d inputs, but got %d", query, want, n)
+		}
+	}
+
+	stmt := &statement{conn: &sqlmock{countPlaceholders: true}, ex: (&ExpectedPrepare{}).WithNumInput(-1), query: "SELECT ?"}
+	if n := stmt.NumInput(); n != -1 {
+		t.Errorf("expected the declared number of inputs -1, but got %d", n)
+	}
+
+	stmt = &statement{conn: &sqlmock{}, ex: &ExpectedPrepare{}, query: "SELECT ?"}
+	if n := stmt.NumInput(); n != -1 {
+		t.Errorf("expected placeholders not to be counted by default, but got %d inputs", n)
+	}
+}
+
+func TestPreparedStatementNotPlaceholders(t *testing.T) {
+	conn, mock, err := New()
+	if err != nil {
+		t.Fatal("failed to open sqlmock database:", err)
+	}
+	defer conn.Close()
+
+	mock.ExpectPrepare("SELECT @@version").ExpectQuery().WillReturnRows(NewRows([]string{"version"}).AddRow("8.0.34"))
+	mock.ExpectPrepare("SELECT id FROM documents").ExpectQuery().WithArgs(1).WillReturnRows(NewRows([]string{"id"}).AddRow(1))
+
+	stmt, err := conn.Prepare("SELECT @@version")
+	if err != nil {
+		t.Fatal("unexpected error while preparing a statement:", err)
+	}
+	defer stmt.Close()
+	var version string
+	if err := stmt.QueryRow().Scan(&version); err != nil {
+		t.Fatal("unexpected error while querying @@version:", err)
+	}
+
+	stmt, err = conn.Prepare("SELECT id FROM documents WHERE data ? 'key' AND id = $1")
+	if err != nil {
+		t.Fatal("unexpected error while preparing a statement:", err)
+	}
+	defer stmt.Close()
+	var id int
+	if err := stmt.QueryRow(1).Scan(&id); err != nil {
+		t.Fatal("unexpected error while querying with the jsonb ? operator:", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPreparedStatementExecutedTimes(t *testing.T) {
+	conn, mock, err := New()
+	if err != nil {
+		t.Fatal("failed to open sqlmock database:", err)
+	}
+	defer conn.Close()
+
+	prep := mock.ExpectPrepare("INSERT INTO items").WillBeExecuted(3)
+	prep.ExpectExec().WillReturnResults(NewResultSequence(1, 1, 1, 1)...)
+
+	stmt, err := conn.Prepare("INSERT INTO items (name) VALUES (?)")
+	if err != nil {
+		t.Fatal("unexpected error while preparing a statement:", err)
+	}
+	for _, name := range []string{"a", "b"} {
+		if _, err := stmt.Exec(name); err != nil {
+			t.Fatal("unexpected error while executing the statement:", err)
+		}
+	}
+
+	err = mock.ExpectationsWereMet()
+	if err == nil || !strings.Contains(err.Error(), "expected prepared statement to be executed 3 times, but it was executed 2 times") {
+		t.Fatalf("expected the executions to be reported, but got: %v", err)
+	}
+
+	if _, err := stmt.Exec("c"); err != nil {
+		t.Fatal("unexpected error while executing the statement:", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPreparedStatementUsedAfterClose(t *testing.T) {
+	stmt := &statement{ex: &ExpectedPrepare{}, query: "SELECT"}
+	if err := stmt.Close(); err != nil {
+		t.Fatal("unexpected error while closing the statement:", err)
+	}
+	if _, err := stmt.Exec([]driver.Value{}); err == nil || !strings.Contains(err.Error(), "used after it was closed") {
+		t.Errorf("expected an error for an exec after close, but got: %v", err)
+	}
+	if _, err := stmt.Query([]driver.Value{}); err == nil || !strings.Contains(err.Error(), "used after it was closed") {
+		t.Errorf("expected an error for a query after close, but got: %v", err)
+	}
+	if err := stmt.Close(); err == nil {
+		t.Error("expected an error for closing the statement twice")
+	}
+}
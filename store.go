@@ -0,0 +1,155 @@
+package sqlmock
+
+import "sync"
+
+// expectationIndex keeps the bookkeeping which lets the mock find
+// the next expectation to trigger without scanning, and locking,
+// every expectation queued in sqlmock.expected. That matters for
+// suites which load thousands of fixtures with LoadMockConfig.
+//
+// Expectations are only ever fulfilled once, so the leading ones
+// which are already fulfilled are skipped for good. In addition,
+// SQL based expectations whose compiled query matches only one
+// exact SQL string are grouped by that string.
+type expectationIndex struct {
+	sync.Mutex
+	head  int                     // number of leading fulfilled expectations
+	exact map[string]*indexBucket // expectations by exact stripped SQL
+	loose int                     // SQL based expectations which could not be indexed
+}
+
+// indexBucket lists expectations with the same exact SQL in order.
+type indexBucket struct {
+	head    int // number of leading fulfilled expectations
+	entries []expectation
+}
+
+// add queues an expectation and indexes it by its SQL when possible.
+func (c *sqlmock) add(e expectation) {
+	c.expected = append(c.expected, e)
+
+	var matcher *sqlMatcher
+	var expectSQL string
+	switch ex := e.(type) {
+	case *ExpectedQuery:
+		matcher, expectSQL = &ex.sqlMatcher, ex.expectSQL
+	case *ExpectedExec:
+		matcher, expectSQL = &ex.sqlMatcher, ex.expectSQL
+	case *ExpectedPrepare:
+		matcher, expectSQL = &ex.sqlMatcher, ex.expectSQL
+	default:
+		return
+	}
+
+	c.index.Lock()
+	defer c.index.Unlock()
+	c.indexSQL(e, matcher, expectSQL)
+}
+
+// matchWith replaces the QueryMatcher of a queued expectation
+// and indexes it again, since its SQL is compiled differently.
+func (c *sqlmock) matchWith(e expectation, m *sqlMatcher, expectSQL string, matcher QueryMatcher) {
+	if c == nil {
+		// expectation which was not queued by a mock
+		*m = sqlMatcher{matcher: matcher}
+		return
+	}
+
+	c.index.Lock()
+	defer c.index.Unlock()
+	c.unindexSQL(e, m)
+	*m = sqlMatcher{matcher: matcher}
+	c.indexSQL(e, m, expectSQL)
+}
+
+func (c *sqlmock) indexSQL(e expectation, m *sqlMatcher, expectSQL string) {
+	m.compile(c.queryMatcher, expectSQL)
+	exact, ok := m.compiled.(exactQuery)
+	if !ok {
+		c.index.loose++
+		return
+	}
+	if c.index.exact == nil {
+		c.index.exact = make(map[string]*indexBucket)
+	}
+	bucket, ok := c.index.exact[exact.exactSQL()]
+	if !ok {
+		bucket = &indexBucket{}
+		c.index.exact[exact.exactSQL()] = bucket
+	}
+	bucket.entries = append(bucket.entries, e)
+}
+
+func (c *sqlmock) unindexSQL(e expectation, m *sqlMatcher) {
+	exact, ok := m.compiled.(exactQuery)
+	if !ok {
+		c.index.loose--
+		return
+	}
+	bucket := c.index.exact[exact.exactSQL()]
+	for i, entry := range bucket.entries {
+		if entry == e {
+			bucket.entries = append(bucket.entries[:i:i], bucket.entries[i+1:]...)
+			if i < bucket.head {
+				bucket.head--
+			}
+			return
+		}
+	}
+}
+
+// pending returns the expectations following the leading ones which
+// are known to be fulfilled, together with the number of those skipped.
+func (c *sqlmock) pending() (int, []expectation) {
+	c.index.Lock()
+	defer c.index.Unlock()
+
+	if c.index.head > len(c.expected) {
+		// expectations were replaced behind our back
+		c.index.head = 0
+	}
+	c.index.head += countFulfilled(c.expected[c.index.head:])
+	return c.index.head, c.expected[c.index.head:]
+}
+
+// candidates returns the unfulfilled expectations which may match the
+// given SQL in the order they were set. It is only possible to narrow
+// them down when every SQL based expectation was indexed, otherwise ok
+// is false and all pending expectations must be considered.
+func (c *sqlmock) candidates(query string) (_ []expectation, ok bool) {
+	c.index.Lock()
+	defer c.index.Unlock()
+
+	if c.index.loose > 0 || c.index.exact == nil {
+		return nil, false
+	}
+	bucket, found := c.index.exact[stripQuery(query)]
+	if !found {
+		return nil, true
+	}
+	bucket.head += countFulfilled(bucket.entries[bucket.head:])
+
+	var unfulfilled []expectation
+	for _, e := range bucket.entries[bucket.head:] {
+		e.Lock()
+		if !e.fulfilled() {
+			unfulfilled = append(unfulfilled, e)
+		}
+		e.Unlock()
+	}
+	return unfulfilled, true
+}
+
+// countFulfilled counts leading fulfilled expectations.
+func countFulfilled(expectations []expectation) (n int) {
+	for _, e := range expectations {
+		e.Lock()
+		fulfilled := e.fulfilled()
+		e.Unlock()
+		if !fulfilled {
+			break
+		}
+		n++
+	}
+	return
+}
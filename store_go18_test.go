@@ -0,0 +1,91 @@
+// +build go1.8
+
+package sqlmock
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"testing"
+)
+
+// linearExec looks up an exec expectation the way the mock did before
+// expectations were indexed: every expectation is visited in order and
+// its SQL is matched by the QueryMatcher, without compiling it once.
+func linearExec(c *sqlmock, query string, args []driver.NamedValue) error {
+	for _, next := range c.expected {
+		next.Lock()
+		if next.fulfilled() {
+			next.Unlock()
+			continue
+		}
+		if exec, ok := next.(*ExpectedExec); ok {
+			if err := c.queryMatcher.Match(exec.expectSQL, query); err == nil {
+				if err := exec.attemptArgMatch(args); err == nil {
+					exec.triggered = true
+					next.Unlock()
+					return nil
+				}
+			}
+		}
+		next.Unlock()
+	}
+	return fmt.Errorf("call to ExecQuery '%s' with args %+v was not expected", query, args)
+}
+
+// indexedExec looks up an exec expectation the way the mock does.
+func indexedExec(c *sqlmock, query string, args []driver.NamedValue) error {
+	_, _, err := c.exec(query, args)
+	return err
+}
+
+// benchmarkLookupPath runs the same unordered fixture suite as
+// benchmarkExpectationLookup through the given lookup, so that the
+// indexed lookup can be compared to the linear one it replaced.
+func benchmarkLookupPath(b *testing.B, lookup func(*sqlmock, string, []driver.NamedValue) error, options ...func(*sqlmock) error) {
+	db, mock, err := New(options...)
+	if err != nil {
+		b.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mock.MatchExpectationsInOrder(false)
+	c := mock.(*sqlmock)
+
+	const fixtures = 1000
+	queries := make([]string, fixtures)
+	for i := range queries {
+		queries[i] = fmt.Sprintf("SELECT name FROM users_%d WHERE id = ?", i)
+	}
+	args := []driver.NamedValue{{Ordinal: 1, Value: int64(1)}}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		for _, query := range queries {
+			mock.ExpectExec(query).WithArgs(1).WillReturnResult(NewResult(0, 1))
+		}
+		b.StartTimer()
+
+		for i := range queries {
+			if err := lookup(c, queries[fixtures-1-i], args); err != nil {
+				b.Fatalf("unexpected error: %s", err)
+			}
+		}
+	}
+}
+
+func BenchmarkLookupLinearEqual(b *testing.B) {
+	benchmarkLookupPath(b, linearExec, QueryMatcherOption(QueryMatcherEqual))
+}
+
+func BenchmarkLookupIndexedEqual(b *testing.B) {
+	benchmarkLookupPath(b, indexedExec, QueryMatcherOption(QueryMatcherEqual))
+}
+
+func BenchmarkLookupLinearRegexp(b *testing.B) {
+	benchmarkLookupPath(b, linearExec)
+}
+
+func BenchmarkLookupIndexedRegexp(b *testing.B) {
+	benchmarkLookupPath(b, indexedExec)
+}
@@ -0,0 +1,191 @@
+package sqlmock
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestUnorderedExactMatchingUsesIndex(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New(QueryMatcherOption(QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mock.MatchExpectationsInOrder(false)
+
+	for i := 0; i < 3; i++ {
+		mock.ExpectExec("UPDATE users SET name = ? WHERE id = ?").
+			WithArgs("john", i).
+			WillReturnResult(NewResult(0, int64(i)))
+	}
+	mock.ExpectExec("DELETE FROM users").WillReturnResult(NewResult(0, 10))
+
+	smock := mock.(*sqlmock)
+	if candidates, ok := smock.candidates("UPDATE users\n SET name = ? WHERE id = ?"); !ok || len(candidates) != 3 {
+		t.Fatalf("expected 3 indexed candidates, but got %d (indexed: %t)", len(candidates), ok)
+	}
+
+	if _, err := db.Exec("DELETE FROM users"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, i := range []int{2, 0, 1} {
+		res, err := db.Exec("UPDATE users SET name = ? WHERE id = ?", "john", i)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if affected, _ := res.RowsAffected(); affected != int64(i) {
+			t.Fatalf("expected expectation %d to be matched, but got %d", i, affected)
+		}
+	}
+
+	if candidates, _ := smock.candidates("UPDATE users SET name = ? WHERE id = ?"); len(candidates) != 0 {
+		t.Fatalf("expected fulfilled candidates to be dropped, but got %d", len(candidates))
+	}
+	if head, _ := smock.pending(); head != 4 {
+		t.Fatalf("expected all 4 expectations to be skipped as fulfilled, but got %d", head)
+	}
+
+	_, err = db.Exec("DELETE FROM users")
+	if err == nil || err.Error() != "all expectations were already fulfilled, call to ExecQuery 'DELETE FROM users' with args [] was not expected" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUnorderedMatchingFallsBackWithoutIndex(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mock.MatchExpectationsInOrder(false)
+
+	mock.ExpectExec("UPDATE users").WillReturnResult(NewResult(0, 1))
+	if _, ok := mock.(*sqlmock).candidates("UPDATE users"); ok {
+		t.Fatal("regular expressions must not be indexed")
+	}
+
+	if _, err := db.Exec("UPDATE users SET name = ?", "john"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConcurrentIndexedMatching(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New(QueryMatcherOption(QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mock.MatchExpectationsInOrder(false)
+
+	const calls = 50
+	for i := 0; i < calls; i++ {
+		mock.ExpectExec("INSERT INTO users (id) VALUES (?)").WithArgs(i).WillReturnResult(NewResult(int64(i), 1))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			if _, err := db.Exec("INSERT INTO users (id) VALUES (?)", id); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func benchmarkExpectationLookup(b *testing.B, ordered bool, options ...func(*sqlmock) error) {
+	db, mock, err := New(options...)
+	if err != nil {
+		b.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mock.MatchExpectationsInOrder(ordered)
+
+	// a fixture suite with many distinct queries, matched in reverse order
+	// when unordered, so that a linear scan would have to walk all of them
+	const fixtures = 1000
+	queries := make([]string, fixtures)
+	for i := range queries {
+		queries[i] = fmt.Sprintf("SELECT name FROM users_%d WHERE id = ?", i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		for _, query := range queries {
+			mock.ExpectExec(query).WithArgs(1).WillReturnResult(NewResult(0, 1))
+		}
+		b.StartTimer()
+
+		for i := range queries {
+			query := queries[i]
+			if !ordered {
+				query = queries[fixtures-1-i]
+			}
+			if _, err := db.Exec(query, 1); err != nil {
+				b.Fatalf("unexpected error: %s", err)
+			}
+		}
+	}
+}
+
+func BenchmarkExpectationLookupOrdered(b *testing.B) {
+	benchmarkExpectationLookup(b, true, QueryMatcherOption(QueryMatcherEqual))
+}
+
+func BenchmarkExpectationLookupUnorderedEqual(b *testing.B) {
+	benchmarkExpectationLookup(b, false, QueryMatcherOption(QueryMatcherEqual))
+}
+
+func BenchmarkExpectationLookupUnorderedRegexp(b *testing.B) {
+	benchmarkExpectationLookup(b, false)
+}
+
+func TestMatchWithReindexesExpectation(t *testing.T) {
+	t.Parallel()
+	db, mock, err := New(QueryMatcherOption(QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mock.MatchExpectationsInOrder(false)
+
+	smock := mock.(*sqlmock)
+	mock.ExpectExec("DELETE FROM users").WillReturnResult(NewResult(0, 1))
+	exec := mock.ExpectExec("UPDATE users SET (.+)").WillReturnResult(NewResult(0, 1))
+	if _, ok := smock.candidates("DELETE FROM users"); !ok {
+		t.Fatal("expected exact expectations to be indexed")
+	}
+
+	exec.MatchWith(QueryMatcherRegexp)
+	if _, ok := smock.candidates("DELETE FROM users"); ok {
+		t.Fatal("expected the index not to be used, once a regular expression is expected")
+	}
+	if bucket := smock.index.exact["UPDATE users SET (.+)"]; len(bucket.entries) != 0 {
+		t.Fatalf("expected the expectation to be removed from the index, but got %d entries", len(bucket.entries))
+	}
+
+	exec.MatchWith(QueryMatcherEqual)
+	if candidates, ok := smock.candidates("UPDATE users SET (.+)"); !ok || len(candidates) != 1 {
+		t.Fatalf("expected the expectation to be indexed again, but got %d candidates (indexed: %t)", len(candidates), ok)
+	}
+}